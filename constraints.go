@@ -0,0 +1,38 @@
+package main
+
+import (
+	"go/build"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/walk"
+)
+
+// buildContext returns a context to select files by build constraints with, it is nil when no constraint
+// options were given
+func (a args) buildContext() *build.Context {
+	return walk.BuildContext(a.Tags, a.GOOS, a.GOARCH)
+}
+
+// logConstraints reports files excluded by build constraints and files whose constraints can't be evaluated
+func logConstraints(logger *zerolog.Logger, c *walk.Constraints) {
+	for _, path := range c.Excluded {
+		logger.Info().Msgf("%s: excluded by build constraints", path)
+	}
+	for _, failure := range c.Unevaluable {
+		logger.Warn().Err(failure.Err).Msgf("%s: failed to evaluate build constraints, processed anyway", failure.Path)
+	}
+	if len(c.Excluded) == 0 {
+		return
+	}
+
+	event := logger.Info().
+		Str("goos", c.Context.GOOS).
+		Str("goarch", c.Context.GOARCH).
+		Strs("tags", c.Context.BuildTags)
+	if len(c.Excluded) == 1 {
+		event.Msg("1 *.go file was excluded by build constraints")
+	} else {
+		event.Msgf("%d *.go files were excluded by build constraints", len(c.Excluded))
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/gomod"
+	"github.com/sirkon/go-imports-rename/internal/modcache"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// runDeprecated looks for deprecated modules required in go.mod of the root and reports or saves changes of their
+// imports to replacements mentioned in deprecation messages
+func runDeprecated(logger *zerolog.Logger, opts options) {
+	root := opts.root
+	modFile, err := gomod.Read(filepath.Join(root, "go.mod"))
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to read go.mod in %s", root)
+		return
+	}
+
+	sources := modcache.Sources()
+	var reps []replacer.Replacer
+	for _, req := range modFile.Require {
+		modulePath := req.Mod.Path
+		message, err := modcache.Deprecation(sources, modulePath)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to check deprecation of %s", modulePath)
+			continue
+		}
+		if message == "" {
+			continue
+		}
+
+		rule, ok := modcache.DeprecationRule(modulePath, message)
+		if !ok {
+			logger.Warn().Str("deprecated", message).Msgf("module %s is deprecated, no replacement recognized", modulePath)
+			continue
+		}
+
+		rep, err := ruleReplacer(rule)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to create a rule for %s", modulePath)
+			continue
+		}
+		logger.Warn().Str("deprecated", message).Msgf("module %s is deprecated, rule '%s'", modulePath, rule)
+		reps = append(reps, rep)
+	}
+
+	if len(reps) == 0 {
+		logger.Info().Msgf("no deprecated modules with known replacements found in %s", filepath.Join(root, "go.mod"))
+		return
+	}
+
+	runRename(logger, opts, replacer.Chain(reps...))
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/doclink"
+	"github.com/sirkon/go-imports-rename/internal/generated"
+	"github.com/sirkon/go-imports-rename/internal/importcomment"
+	"github.com/sirkon/go-imports-rename/internal/linkname"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/walk"
+)
+
+// renameDirectives applies the replacer to import paths of //go:generate and //go:linkname directives of the file.
+// Changes are reported unless save is set, it returns the amount of changes. Imports are import paths of the file
+// after the rename, changed linkname targets out of them and out of the file's own package are reported
+func renameDirectives(
+	logger *zerolog.Logger,
+	path string,
+	goFile *ast.File,
+	imports map[string]struct{},
+	rep replacer.Replacer,
+	save bool,
+) int {
+	var res int
+	for _, group := range goFile.Comments {
+		for _, comment := range group.List {
+			if directive, ok := linkname.Parse(comment.Text); ok {
+				res += renameLinkname(logger, path, comment, directive, imports, rep, save)
+				continue
+			}
+
+			text, changes := generated.RewriteDirective(comment.Text, rep)
+			for _, change := range changes {
+				if !save {
+					logger.Info().Msgf("%s: go:generate %s => %s", path, change.Old, change.New)
+				}
+			}
+			if save {
+				comment.Text = text
+			}
+			res += len(changes)
+		}
+	}
+	return res
+}
+
+// renameLinkname applies the replacer to the target of //go:linkname directive
+func renameLinkname(
+	logger *zerolog.Logger,
+	path string,
+	comment *ast.Comment,
+	directive linkname.Directive,
+	imports map[string]struct{},
+	rep replacer.Replacer,
+	save bool,
+) int {
+	if directive.Path == "" {
+		return 0
+	}
+
+	var res int
+	if v, ok := rep.Replace(directive.Path).(replacer.Replacement); ok {
+		old := directive.Target()
+		directive.Path = v.String()
+		if save {
+			comment.Text = directive.String()
+		} else {
+			logger.Info().Msgf("%s: go:linkname %s => %s", path, old, directive.Target())
+		}
+		res++
+	}
+
+	// only linknames changed by the rule are checked, runtime is always linked in
+	if res == 0 || directive.Path == "runtime" {
+		return res
+	}
+	if _, ok := imports[directive.Path]; ok || isOwnPackage(path, directive.Path, rep) {
+		return res
+	}
+	logger.Warn().Msgf(
+		"%s: go:linkname %s target package %s is not imported in the file",
+		path,
+		directive.Local,
+		directive.Path,
+	)
+	return res
+}
+
+// isOwnPackage checks if the import path is the one of the package of the file, before or after the rename
+func isOwnPackage(path, importPath string, rep replacer.Replacer) bool {
+	own, ok := walk.ImportPath(filepath.Dir(path))
+	if !ok {
+		return false
+	}
+	if v, ok := rep.Replace(own).(replacer.Replacement); ok {
+		return importPath == v.String()
+	}
+	return importPath == own
+}
+
+// renameImportComment applies the replacer to the import comment of the package clause or removes the comment if
+// strip is set
+func renameImportComment(
+	logger *zerolog.Logger,
+	path string,
+	fset *token.FileSet,
+	goFile *ast.File,
+	rep replacer.Replacer,
+	save bool,
+	strip bool,
+) int {
+	comment, ok := importcomment.Find(fset, goFile)
+	if !ok {
+		return 0
+	}
+
+	if strip {
+		if save {
+			importcomment.Strip(goFile, comment)
+		} else {
+			logger.Info().Msgf("%s: import comment %s removed", path, comment.Path)
+		}
+		return 1
+	}
+
+	v, ok := rep.Replace(comment.Path).(replacer.Replacement)
+	if !ok {
+		return 0
+	}
+	if save {
+		comment.Comment.Text = importcomment.Rewrite(comment.Comment.Text, v.String())
+	} else {
+		logger.Info().Msgf("%s: import comment %s => %s", path, comment.Path, v.String())
+	}
+	return 1
+}
+
+// renameDocLinks applies the replacer to import paths of doc links in comments of the file
+func renameDocLinks(logger *zerolog.Logger, path string, goFile *ast.File, rep replacer.Replacer, save bool) int {
+	var res int
+	for _, group := range goFile.Comments {
+		for _, comment := range group.List {
+			text, changes := doclink.Rewrite(comment.Text, rep)
+			for _, change := range changes {
+				if !save {
+					logger.Info().Msgf("%s: doc link %s => %s", path, change.Old, change.New)
+				}
+			}
+			if save {
+				comment.Text = text
+			}
+			res += len(changes)
+		}
+	}
+	return res
+}
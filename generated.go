@@ -0,0 +1,121 @@
+package main
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/generated"
+)
+
+// generatedPolicy how generated files are treated
+type generatedPolicy string
+
+const (
+	generatedPolicyInclude generatedPolicy = "include"
+	generatedPolicySkip    generatedPolicy = "skip"
+	generatedPolicyReport  generatedPolicy = "report"
+)
+
+func parseGeneratedPolicy(value string) (generatedPolicy, error) {
+	switch policy := generatedPolicy(value); policy {
+	case "":
+		return generatedPolicyInclude, nil
+	case generatedPolicyInclude, generatedPolicySkip, generatedPolicyReport:
+		return policy, nil
+	default:
+		return "", errors.Errorf("unsupported generated files policy %s, one of include, skip or report expected", value)
+	}
+}
+
+// generatedFile a generated file having imports to change
+type generatedFile struct {
+	path    string
+	changes int
+	command string
+}
+
+// generatedReport changes found in generated files
+type generatedReport struct {
+	files   []generatedFile
+	changes int
+	// directives[dir] are //go:generate directives of the directory
+	directives map[string][]generated.Directive
+}
+
+// add registers changes of the generated file
+func (r *generatedReport) add(policy generatedPolicy, path string, file *ast.File, changes int) {
+	if changes == 0 {
+		return
+	}
+	r.changes += changes
+
+	item := generatedFile{
+		path:    path,
+		changes: changes,
+	}
+	if policy == generatedPolicyReport {
+		directive, ok := generated.GuessCommand(path, generated.Generator(file), r.dirDirectives(filepath.Dir(path)))
+		if ok {
+			item.command = directive.Command
+		}
+	}
+	r.files = append(r.files, item)
+}
+
+// dirDirectives reads //go:generate directives of the directory once
+func (r *generatedReport) dirDirectives(dir string) []generated.Directive {
+	if directives, ok := r.directives[dir]; ok {
+		return directives
+	}
+	if r.directives == nil {
+		r.directives = map[string][]generated.Directive{}
+	}
+
+	var res []generated.Directive
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		res = append(res, generated.Directives(entry.Name(), data)...)
+	}
+	r.directives[dir] = res
+	return res
+}
+
+func (r *generatedReport) log(logger *zerolog.Logger, policy generatedPolicy) {
+	if policy == generatedPolicyReport {
+		for _, file := range r.files {
+			event := logger.Info().Int("imports", file.changes)
+			if file.command != "" {
+				event.Msgf("%s: generated file, regenerate it with `%s`", file.path, file.command)
+			} else {
+				event.Msgf("%s: generated file, no //go:generate directive found for it", file.path)
+			}
+		}
+	}
+	if r.changes == 0 {
+		return
+	}
+
+	var action string
+	switch policy {
+	case generatedPolicyInclude:
+		action = "changed as well"
+	default:
+		action = "left untouched"
+	}
+	logger.Warn().
+		Int("files", len(r.files)).
+		Int("imports", r.changes).
+		Msgf("matching imports in generated code were %s, update generator inputs and regenerate", action)
+}
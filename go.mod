@@ -7,6 +7,8 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/rs/zerolog v1.15.0
 	github.com/sirkon/gosrcfmt v1.6.0
+	golang.org/x/mod v0.12.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require github.com/alexflint/go-scalar v1.0.0 // indirect
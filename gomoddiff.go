@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"golang.org/x/mod/modfile"
+
+	"github.com/sirkon/go-imports-rename/internal/gomod"
+)
+
+// runGoModDiff infers rules from module path changes between two go.mod files and writes them into the output
+func runGoModDiff(logger *zerolog.Logger, root, beforeSpec, afterSpec, output string) {
+	before, err := readGoModSpec(root, beforeSpec)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to read %s", beforeSpec)
+		return
+	}
+	after, err := readGoModSpec(root, afterSpec)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to read %s", afterSpec)
+		return
+	}
+
+	delta := gomod.Diff(before, after)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# rules inferred from go.mod changes between %s and %s\n", beforeSpec, afterSpec)
+	for _, rule := range delta.Rules {
+		buf.WriteString(rule.String())
+		buf.WriteByte('\n')
+	}
+	for _, rule := range delta.Suggestions {
+		logger.Warn().Msgf("'%s' is only suggested as these modules live in different repositories", rule)
+		fmt.Fprintf(&buf, "# suggested, uncomment after review: %s\n", rule)
+	}
+	for _, p := range delta.Removed {
+		logger.Warn().Msgf("no match found for removed module %s", p)
+		fmt.Fprintf(&buf, "# removed without a match: %s\n", p)
+	}
+	for _, p := range delta.Added {
+		fmt.Fprintf(&buf, "# added without a match: %s\n", p)
+	}
+
+	if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		logger.Error().Err(err).Msgf("failed to write rules into %s", output)
+		return
+	}
+
+	if len(delta.Rules) == 0 && len(delta.Suggestions) == 0 {
+		logger.Warn().Msgf("no module path changes found between %s and %s", beforeSpec, afterSpec)
+		return
+	}
+	logger.Info().Int("rules", len(delta.Rules)).Int("suggestions", len(delta.Suggestions)).Msgf("rules were written into %s, review them and apply with --rules %s", output, output)
+}
+
+// readGoModSpec reads go.mod either from a file or from a git revision given as REV:PATH
+func readGoModSpec(root, spec string) (*modfile.File, error) {
+	if _, err := os.Stat(spec); err == nil {
+		return gomod.Read(spec)
+	}
+	if !strings.Contains(spec, ":") {
+		return nil, errors.Errorf("%s is neither a file nor a git REV:PATH", spec)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "show", spec)
+	cmd.Dir = root
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "git show %s: %s", spec, strings.TrimSpace(stderr.String()))
+	}
+
+	modFile, err := modfile.Parse(spec, data, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse go.mod")
+	}
+	return modFile, nil
+}
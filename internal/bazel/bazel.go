@@ -0,0 +1,188 @@
+package bazel
+
+import (
+	"strings"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+const gazellePrefix = "gazelle:"
+
+// IsBuildFile checks if the file name is a name of Bazel BUILD or WORKSPACE file
+func IsBuildFile(name string) bool {
+	switch name {
+	case "BUILD", "BUILD.bazel", "WORKSPACE", "WORKSPACE.bazel":
+		return true
+	default:
+		return false
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenComment
+	tokenPunct
+)
+
+// token a token of a Starlark file. Strings values are their contents without quotes and prefixes, comments values
+// are their texts after #
+type token struct {
+	kind  tokenKind
+	value string
+	start int
+}
+
+// tokenize splits Starlark source into tokens skipping spaces
+func tokenize(data []byte) []token {
+	src := string(data)
+	var res []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\\':
+			i++
+		case c == '#':
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			res = append(res, token{kind: tokenComment, value: src[i+1 : i+end], start: i + 1})
+			i += end
+		case c == '"' || c == '\'':
+			tok, next := scanString(src, i)
+			res = append(res, tok)
+			i = next
+		case isIdentChar(c):
+			j := i
+			for j < len(src) && isIdentChar(src[j]) {
+				j++
+			}
+			// string prefixes like r"..." or b'...'
+			if j < len(src) && (src[j] == '"' || src[j] == '\'') && isStringPrefix(src[i:j]) {
+				tok, next := scanString(src, j)
+				res = append(res, tok)
+				i = next
+				continue
+			}
+			res = append(res, token{kind: tokenIdent, value: src[i:j], start: i})
+			i = j
+		default:
+			res = append(res, token{kind: tokenPunct, value: string(c), start: i})
+			i++
+		}
+	}
+	return res
+}
+
+// scanString scans a string literal starting at the quote, triple quoted strings included
+func scanString(src string, i int) (token, int) {
+	quote := src[i : i+1]
+	if strings.HasPrefix(src[i:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	start := i + len(quote)
+	for j := start; j < len(src); j++ {
+		switch {
+		case src[j] == '\\':
+			j++
+		case strings.HasPrefix(src[j:], quote):
+			return token{kind: tokenString, value: src[start:j], start: start}, j + len(quote)
+		case src[j] == '\n' && len(quote) == 1:
+			return token{kind: tokenString, value: src[start:j], start: start}, j
+		}
+	}
+	return token{kind: tokenString, value: src[start:], start: start}, len(src)
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isStringPrefix(prefix string) bool {
+	switch strings.ToLower(prefix) {
+	case "r", "b", "rb", "br":
+		return true
+	default:
+		return false
+	}
+}
+
+// Rename applies the replacer to importpath attributes of rules like go_library or go_repository and to import
+// paths of # gazelle:prefix and # gazelle:resolve directives
+func Rename(data []byte, rep replacer.Replacer) []textedit.Edit {
+	var res []textedit.Edit
+	replace := func(start int, value string) {
+		if v, ok := rep.Replace(value).(replacer.Replacement); ok {
+			res = append(res, textedit.NewEdit(data, start, start+len(value), v.String()))
+		}
+	}
+
+	tokens := tokenize(data)
+	for i, tok := range tokens {
+		switch tok.kind {
+		case tokenIdent:
+			// importpath = "..."
+			if tok.value != "importpath" || i+2 >= len(tokens) {
+				continue
+			}
+			if tokens[i+1].kind != tokenPunct || tokens[i+1].value != "=" || tokens[i+2].kind != tokenString {
+				continue
+			}
+			replace(tokens[i+2].start, tokens[i+2].value)
+
+		case tokenComment:
+			start, args := directiveArgs(tok)
+			if len(args) == 0 {
+				continue
+			}
+			switch args[0] {
+			case "prefix":
+				// # gazelle:prefix github.com/user/project
+				if len(args) == 2 {
+					replace(start[1], args[1])
+				}
+			case "resolve":
+				// # gazelle:resolve go github.com/user/lib //label or
+				// # gazelle:resolve go go github.com/user/lib //label
+				switch len(args) {
+				case 4:
+					replace(start[2], args[2])
+				case 5:
+					replace(start[3], args[3])
+				}
+			}
+		}
+	}
+	return res
+}
+
+// directiveArgs splits a gazelle directive comment into the directive name and its arguments with their offsets
+func directiveArgs(tok token) ([]int, []string) {
+	text := strings.TrimLeft(tok.value, " \t")
+	if !strings.HasPrefix(text, gazellePrefix) {
+		return nil, nil
+	}
+	offset := tok.start + len(tok.value) - len(text) + len(gazellePrefix)
+	text = text[len(gazellePrefix):]
+
+	var starts []int
+	var args []string
+	for i := 0; i < len(text); {
+		if text[i] == ' ' || text[i] == '\t' || text[i] == '\r' {
+			i++
+			continue
+		}
+		j := i
+		for j < len(text) && text[j] != ' ' && text[j] != '\t' && text[j] != '\r' {
+			j++
+		}
+		starts = append(starts, offset+i)
+		args = append(args, text[i:j])
+		i = j
+	}
+	return starts, args
+}
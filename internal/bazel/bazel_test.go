@@ -0,0 +1,84 @@
+package bazel
+
+import (
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+func TestRename(t *testing.T) {
+	input := `load("@io_bazel_rules_go//go:def.bzl", "go_library")
+
+# gazelle:prefix github.com/user/project
+# gazelle:resolve go github.com/user/lib //third_party/lib
+# gazelle:resolve go go github.com/user/lib/sub //third_party/lib/sub
+# importpath = "github.com/user/commented"
+
+go_library(
+    name = "project",
+    srcs = ["project.go"],
+    importpath = "github.com/user/project",
+    visibility = ["//visibility:public"],
+    x_defs = {"importpath": "github.com/user/project"},
+)
+
+go_repository(
+    name = "com_github_user_lib",
+    importpath = 'github.com/user/lib',
+    doc = """
+importpath = "github.com/user/lib"
+""",
+    sum = "h1:abc=",
+    version = "v1.0.0",
+)
+`
+	want := `load("@io_bazel_rules_go//go:def.bzl", "go_library")
+
+# gazelle:prefix github.com/org/project
+# gazelle:resolve go github.com/org/lib //third_party/lib
+# gazelle:resolve go go github.com/org/lib/sub //third_party/lib/sub
+# importpath = "github.com/user/commented"
+
+go_library(
+    name = "project",
+    srcs = ["project.go"],
+    importpath = "github.com/org/project",
+    visibility = ["//visibility:public"],
+    x_defs = {"importpath": "github.com/user/project"},
+)
+
+go_repository(
+    name = "com_github_user_lib",
+    importpath = 'github.com/org/lib',
+    doc = """
+importpath = "github.com/user/lib"
+""",
+    sum = "h1:abc=",
+    version = "v1.0.0",
+)
+`
+
+	edits := Rename([]byte(input), replacer.Prefix("github.com/user/", "github.com/org/"))
+	if got := string(textedit.Apply([]byte(input), edits)); got != want {
+		t.Errorf("Rename() resulted in\n%s\nwant\n%s", got, want)
+	}
+	if len(edits) != 5 {
+		t.Errorf("Rename() made %d edits, want 5", len(edits))
+	}
+}
+
+func TestIsBuildFile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"BUILD":           true,
+		"BUILD.bazel":     true,
+		"WORKSPACE":       true,
+		"WORKSPACE.bazel": true,
+		"build.go":        false,
+		"BUILD.txt":       false,
+	} {
+		if got := IsBuildFile(name); got != want {
+			t.Errorf("IsBuildFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package doclink
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/sirkon/go-imports-rename/internal/importpath"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// linkRe matches doc links like [github.com/user/pkg], [github.com/user/pkg.Name] or [*github.com/user/pkg.Name.Method]
+var linkRe = regexp.MustCompile(`\[\*?([^\[\]\s]+)\]`)
+
+// Change a change of a doc link import path
+type Change struct {
+	Old string
+	New string
+}
+
+// Rewrite applies the replacer to import paths of doc links of the comment text leaving the rest of it untouched.
+// Only links with full import paths are looked at, i.e. [pkg.Name] links are not
+func Rewrite(text string, rep replacer.Replacer) (string, []Change) {
+	var buf strings.Builder
+	var changes []Change
+	var last int
+	for _, match := range linkRe.FindAllStringSubmatchIndex(text, -1) {
+		// link definitions look like [text]: URL
+		if match[1] < len(text) && text[match[1]] == ':' {
+			continue
+		}
+
+		start, end := match[2], match[3]
+		path, ok := linkPath(text[start:end])
+		if !ok {
+			continue
+		}
+		v, ok := rep.Replace(path).(replacer.Replacement)
+		if !ok {
+			continue
+		}
+
+		buf.WriteString(text[last:start])
+		buf.WriteString(v.String())
+		last = start + len(path)
+		changes = append(changes, Change{
+			Old: path,
+			New: v.String(),
+		})
+	}
+	if len(changes) == 0 {
+		return text, nil
+	}
+
+	buf.WriteString(text[last:])
+	return buf.String(), changes
+}
+
+// linkPath returns an import path of the link target: the part before the first dot of its last path element
+// followed by an exported name or the whole target if there is no such dot. Dots before lower case words are kept
+// as they are parts of paths, i.e. gopkg.in/yaml.v2
+func linkPath(target string) (string, bool) {
+	if !strings.Contains(target, "/") {
+		return "", false
+	}
+	path := target
+	for i := strings.LastIndexByte(target, '/') + 1; i < len(target)-1; i++ {
+		if target[i] == '.' && unicode.IsUpper(rune(target[i+1])) {
+			path = target[:i]
+			break
+		}
+	}
+	return path, importpath.IsRemote(path)
+}
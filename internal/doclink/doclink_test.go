@@ -0,0 +1,62 @@
+package doclink
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+func TestRewrite(t *testing.T) {
+	rep := replacer.Chain(
+		replacer.Prefix("github.com/org/", "github.com/neworg/"),
+		replacer.Prefix("gopkg.in/org/", "gopkg.in/neworg/"),
+	)
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		changes []Change
+	}{
+		{
+			name:    "name",
+			text:    "// Foo wraps [github.com/org/pkg.Func] and [*github.com/org/pkg.Type.Method].",
+			want:    "// Foo wraps [github.com/neworg/pkg.Func] and [*github.com/neworg/pkg.Type.Method].",
+			changes: []Change{{Old: "github.com/org/pkg", New: "github.com/neworg/pkg"}, {Old: "github.com/org/pkg", New: "github.com/neworg/pkg"}},
+		},
+		{
+			name:    "package",
+			text:    "/*\nSee [github.com/org/pkg] for details.\n*/",
+			want:    "/*\nSee [github.com/neworg/pkg] for details.\n*/",
+			changes: []Change{{Old: "github.com/org/pkg", New: "github.com/neworg/pkg"}},
+		},
+		{
+			name:    "dotted-path",
+			text:    "// Use [gopkg.in/org/yaml.v2.Unmarshal] instead.",
+			want:    "// Use [gopkg.in/neworg/yaml.v2.Unmarshal] instead.",
+			changes: []Change{{Old: "gopkg.in/org/yaml.v2", New: "gopkg.in/neworg/yaml.v2"}},
+		},
+		{
+			name: "short-links",
+			text: "// Uses [pkg.Type] and [Name], [github.com/org/pkg]: https://example.com",
+			want: "// Uses [pkg.Type] and [Name], [github.com/org/pkg]: https://example.com",
+		},
+		{
+			name: "other-paths",
+			text: "// Uses [github.com/other/pkg.Type] and github.com/org/pkg.Type",
+			want: "// Uses [github.com/other/pkg.Type] and github.com/org/pkg.Type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changes := Rewrite(tt.text, rep)
+			if got != tt.want {
+				t.Errorf("Rewrite() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("Rewrite() changes = %v, want %v", changes, tt.changes)
+			}
+		})
+	}
+}
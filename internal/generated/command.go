@@ -0,0 +1,96 @@
+package generated
+
+import (
+	"strings"
+
+	"github.com/sirkon/go-imports-rename/internal/importpath"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// Change a change of an import path in a //go:generate command
+type Change struct {
+	Old string
+	New string
+}
+
+// arg a command argument, start and end point at its value in the command, i.e. without quotes
+type arg struct {
+	start int
+	end   int
+}
+
+// splitArgs splits a command into arguments the way go generate does: by spaces with double quoted arguments
+// kept whole
+func splitArgs(command string) []arg {
+	var res []arg
+	for i := 0; i < len(command); {
+		switch command[i] {
+		case ' ', '\t':
+			i++
+		case '"':
+			end := strings.IndexByte(command[i+1:], '"')
+			if end < 0 {
+				res = append(res, arg{start: i + 1, end: len(command)})
+				return res
+			}
+			res = append(res, arg{start: i + 1, end: i + 1 + end})
+			i += end + 2
+		default:
+			end := strings.IndexAny(command[i:], " \t")
+			if end < 0 {
+				end = len(command) - i
+			}
+			res = append(res, arg{start: i, end: i + end})
+			i += end
+		}
+	}
+	return res
+}
+
+// RewriteCommand applies the replacer to arguments of the command which look like import paths or path@version
+// specs. Values of -flag=value arguments are looked at as well
+func RewriteCommand(command string, rep replacer.Replacer) (string, []Change) {
+	var buf strings.Builder
+	var changes []Change
+	var last int
+	for _, a := range splitArgs(command) {
+		start := a.start
+		value := command[a.start:a.end]
+		if strings.HasPrefix(value, "-") {
+			pos := strings.IndexByte(value, '=')
+			if pos < 0 {
+				continue
+			}
+			start += pos + 1
+			value = value[pos+1:]
+		}
+
+		newValue, ok := importpath.Replace(value, rep)
+		if !ok {
+			continue
+		}
+
+		buf.WriteString(command[last:start])
+		buf.WriteString(newValue)
+		last = a.end
+		changes = append(changes, Change{
+			Old: strings.SplitN(value, "@", 2)[0],
+			New: strings.SplitN(newValue, "@", 2)[0],
+		})
+	}
+	if len(changes) == 0 {
+		return command, nil
+	}
+
+	buf.WriteString(command[last:])
+	return buf.String(), changes
+}
+
+// RewriteDirective applies the replacer to a //go:generate directive comment, other comments are left as is
+func RewriteDirective(comment string, rep replacer.Replacer) (string, []Change) {
+	if !strings.HasPrefix(comment, directivePrefix) {
+		return comment, nil
+	}
+	command, changes := RewriteCommand(strings.TrimPrefix(comment, directivePrefix), rep)
+	return directivePrefix + command, changes
+}
@@ -0,0 +1,115 @@
+package generated
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// headerRe is the standard header of generated files, see https://golang.org/s/generatedcode
+var headerRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// generatorRe extracts a generator name from the header
+var generatorRe = regexp.MustCompile(`^// Code generated by (\S+?)\.? .*DO NOT EDIT\.$`)
+
+const directivePrefix = "//go:generate "
+
+// IsGenerated checks if the file has the standard generated code header before its package clause. The file must be
+// parsed with comments
+func IsGenerated(file *ast.File) bool {
+	return header(file) != ""
+}
+
+// Generator returns a generator name from the header of the file, it is empty when the header doesn't mention it
+func Generator(file *ast.File) string {
+	if match := generatorRe.FindStringSubmatch(header(file)); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func header(file *ast.File) string {
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if headerRe.MatchString(comment.Text) {
+				return comment.Text
+			}
+		}
+	}
+	return ""
+}
+
+// Directive a //go:generate directive
+type Directive struct {
+	// File a name of the file the directive was found in
+	File string
+	// Command a command of the directive
+	Command string
+}
+
+// Directives looks for //go:generate directives in the source
+func Directives(fileName string, src []byte) []Directive {
+	var res []Directive
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(nil, len(src)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, directivePrefix) {
+			continue
+		}
+		res = append(res, Directive{
+			File:    fileName,
+			Command: strings.TrimSpace(strings.TrimPrefix(line, directivePrefix)),
+		})
+	}
+	return res
+}
+
+// GuessCommand picks a directive which most likely produces the generated file out of directives of its directory:
+// the one mentioning the file name, then the one mentioning its stem (foo for foo.pb.go, mock_foo.go or
+// foo_string.go), then the only one mentioning the generator, then the only directive of the directory
+func GuessCommand(fileName, generator string, directives []Directive) (Directive, bool) {
+	base := path.Base(fileName)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	if pos := strings.IndexByte(stem, '.'); pos >= 0 {
+		stem = stem[:pos]
+	}
+	stem = strings.TrimPrefix(stem, "mock_")
+	for _, suffix := range []string{"_test", "_string", "_mock", "_gen"} {
+		stem = strings.TrimSuffix(stem, suffix)
+	}
+
+	checks := []func(d Directive) bool{
+		func(d Directive) bool {
+			return strings.Contains(d.Command, base)
+		},
+		func(d Directive) bool {
+			return stem != "" && strings.Contains(d.Command, stem)
+		},
+		func(d Directive) bool {
+			return generator != "" && strings.Contains(d.Command, path.Base(generator))
+		},
+		func(d Directive) bool {
+			return true
+		},
+	}
+	for _, check := range checks {
+		var found []Directive
+		for _, d := range directives {
+			if check(d) {
+				found = append(found, d)
+			}
+		}
+		if len(found) == 1 {
+			return found[0], true
+		}
+	}
+
+	return Directive{}, false
+}
@@ -0,0 +1,204 @@
+package generated
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+func TestIsGenerated(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		want      bool
+		generator string
+	}{
+		{
+			name:      "protoc",
+			src:       "// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: foo.proto\n\npackage foo\n",
+			want:      true,
+			generator: "protoc-gen-go",
+		},
+		{
+			name:      "mockgen",
+			src:       "// Code generated by MockGen. DO NOT EDIT.\n// Source: foo.go\n\n// Package mock is a generated GoMock package.\npackage mock\n",
+			want:      true,
+			generator: "MockGen",
+		},
+		{
+			name: "no-generator",
+			src:  "// Code generated automatically. DO NOT EDIT.\n\npackage foo\n",
+			want: true,
+		},
+		{
+			name: "after-package",
+			src:  "package foo\n\n// Code generated by hand. DO NOT EDIT.\n",
+			want: false,
+		},
+		{
+			name: "not-exact",
+			src:  "// Code generated by hand, do not edit\n\npackage foo\n",
+			want: false,
+		},
+		{
+			name: "ordinary",
+			src:  "// Package foo does things.\npackage foo\n",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parser.ParseFile(token.NewFileSet(), "foo.go", tt.src, parser.ParseComments|parser.PackageClauseOnly)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := IsGenerated(file); got != tt.want {
+				t.Errorf("IsGenerated() = %v, want %v", got, tt.want)
+			}
+			if got := Generator(file); got != tt.generator {
+				t.Errorf("Generator() = %q, want %q", got, tt.generator)
+			}
+		})
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	src := `package foo
+
+//go:generate protoc --go_out=. foo.proto
+//go:generate   mockgen -source=foo.go -destination=mock_foo.go
+// go:generate not a directive
+`
+	want := []Directive{
+		{File: "gen.go", Command: "protoc --go_out=. foo.proto"},
+		{File: "gen.go", Command: "mockgen -source=foo.go -destination=mock_foo.go"},
+	}
+	if got := Directives("gen.go", []byte(src)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Directives() = %v, want %v", got, want)
+	}
+}
+
+func TestGuessCommand(t *testing.T) {
+	directives := []Directive{
+		{File: "gen.go", Command: "protoc --go_out=. foo.proto"},
+		{File: "gen.go", Command: "mockgen -source=bar.go -destination=mock_bar.go"},
+		{File: "types.go", Command: "stringer -type=Kind"},
+	}
+
+	tests := []struct {
+		name       string
+		file       string
+		generator  string
+		directives []Directive
+		want       string
+		ok         bool
+	}{
+		{
+			name:       "file-name",
+			file:       "mock_bar.go",
+			generator:  "MockGen",
+			directives: directives,
+			want:       "mockgen -source=bar.go -destination=mock_bar.go",
+			ok:         true,
+		},
+		{
+			name:       "stem",
+			file:       "foo.pb.go",
+			generator:  "protoc-gen-go",
+			directives: directives,
+			want:       "protoc --go_out=. foo.proto",
+			ok:         true,
+		},
+		{
+			name:       "generator",
+			file:       "kind_string.go",
+			generator:  "stringer",
+			directives: directives,
+			want:       "stringer -type=Kind",
+			ok:         true,
+		},
+		{
+			name:       "single",
+			file:       "whatever.go",
+			directives: directives[:1],
+			want:       "protoc --go_out=. foo.proto",
+			ok:         true,
+		},
+		{
+			name:       "ambiguous",
+			file:       "whatever.go",
+			directives: directives,
+		},
+		{
+			name: "none",
+			file: "whatever.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GuessCommand(tt.file, tt.generator, tt.directives)
+			if ok != tt.ok || got.Command != tt.want {
+				t.Errorf("GuessCommand() = %q, %v, want %q, %v", got.Command, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRewriteCommand(t *testing.T) {
+	rep := replacer.Prefix("github.com/org/", "github.com/neworg/")
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		changes []Change
+	}{
+		{
+			name:    "go-run-version",
+			command: "go run github.com/org/tools/cmd/gen@v1.2.0 -out gen.go",
+			want:    "go run github.com/neworg/tools/cmd/gen@v1.2.0 -out gen.go",
+			changes: []Change{{Old: "github.com/org/tools/cmd/gen", New: "github.com/neworg/tools/cmd/gen"}},
+		},
+		{
+			name:    "mockgen-reflect",
+			command: "mockgen -destination mock.go   github.com/org/lib/iface Iface",
+			want:    "mockgen -destination mock.go   github.com/neworg/lib/iface Iface",
+			changes: []Change{{Old: "github.com/org/lib/iface", New: "github.com/neworg/lib/iface"}},
+		},
+		{
+			name:    "flag-value-and-quotes",
+			command: `mockgen -self_package=github.com/org/lib/iface "github.com/org/lib/other"`,
+			want:    `mockgen -self_package=github.com/neworg/lib/iface "github.com/neworg/lib/other"`,
+			changes: []Change{
+				{Old: "github.com/org/lib/iface", New: "github.com/neworg/lib/iface"},
+				{Old: "github.com/org/lib/other", New: "github.com/neworg/lib/other"},
+			},
+		},
+		{
+			name:    "files-are-left-alone",
+			command: "mockgen -source=iface.go -destination=./mocks/github.com/org/iface.go",
+			want:    "mockgen -source=iface.go -destination=./mocks/github.com/org/iface.go",
+		},
+		{
+			name:    "no-match",
+			command: "go run github.com/other/tool",
+			want:    "go run github.com/other/tool",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changes := RewriteCommand(tt.command, rep)
+			if got != tt.want {
+				t.Errorf("RewriteCommand() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("RewriteCommand() changes = %v, want %v", changes, tt.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package golangci
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+// FileNames names of golangci-lint YAML configuration files
+var FileNames = []string{".golangci.yml", ".golangci.yaml"}
+
+// gciPrefixRe matches prefix(path,path...) sections of gci
+var gciPrefixRe = regexp.MustCompile(`^prefix\((.*)\)$`)
+
+// Rename applies the replacer to import paths of known golangci-lint settings: goimports local-prefixes, gci sections
+// and local-prefixes, depguard allow and deny lists and importas aliases. Settings are looked for by linter names
+// so that both linters-settings and linters.settings layouts are supported. Edits keep comments and formatting
+// of the file intact
+func Rename(data []byte, rep replacer.Replacer) ([]textedit.Edit, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.WithMessage(err, "parse golangci-lint configuration")
+	}
+
+	r := &renamer{
+		data:  data,
+		lines: lineStarts(data),
+		rep:   rep,
+	}
+	r.walk(&doc)
+	return r.edits, nil
+}
+
+type renamer struct {
+	data  []byte
+	lines []int
+	rep   replacer.Replacer
+	edits []textedit.Edit
+}
+
+// walk looks for settings of linters of interest
+func (r *renamer) walk(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch key.Value {
+			case "goimports":
+				r.goimports(value)
+				continue
+			case "gci":
+				r.gci(value)
+				continue
+			case "depguard":
+				r.depguard(value)
+				continue
+			case "importas":
+				r.importas(value)
+				continue
+			}
+			r.walk(value)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		r.walk(child)
+	}
+}
+
+func (r *renamer) goimports(node *yaml.Node) {
+	r.forKey(node, "local-prefixes", r.prefixes)
+}
+
+func (r *renamer) gci(node *yaml.Node) {
+	r.forKey(node, "local-prefixes", r.prefixes)
+	r.forKey(node, "sections", func(sections *yaml.Node) {
+		for _, section := range sections.Content {
+			match := gciPrefixRe.FindStringSubmatch(section.Value)
+			if section.Kind != yaml.ScalarNode || match == nil {
+				continue
+			}
+			r.list(section, match[1], len("prefix("))
+		}
+	})
+}
+
+func (r *renamer) depguard(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "allow", "deny", "packages":
+			for _, item := range value.Content {
+				switch item.Kind {
+				case yaml.ScalarNode:
+					r.path(item, item.Value, 0)
+				case yaml.MappingNode:
+					r.forKey(item, "pkg", func(pkg *yaml.Node) {
+						r.path(pkg, pkg.Value, 0)
+					})
+				}
+			}
+		case "packages-with-error-message":
+			// a list of maps keyed by paths
+			for _, item := range value.Content {
+				for j := 0; j+1 < len(item.Content); j += 2 {
+					r.path(item.Content[j], item.Content[j].Value, 0)
+				}
+			}
+		default:
+			r.depguard(value)
+		}
+	}
+}
+
+func (r *renamer) importas(node *yaml.Node) {
+	r.forKey(node, "alias", func(aliases *yaml.Node) {
+		for _, alias := range aliases.Content {
+			r.forKey(alias, "pkg", func(pkg *yaml.Node) {
+				r.path(pkg, pkg.Value, 0)
+			})
+		}
+	})
+}
+
+// forKey calls fn for the value of the key if the node is a mapping having it
+func (r *renamer) forKey(node *yaml.Node, key string, fn func(value *yaml.Node)) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			fn(node.Content[i+1])
+		}
+	}
+}
+
+// prefixes processes either a comma separated list of prefixes or a sequence of them
+func (r *renamer) prefixes(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		r.list(node, node.Value, 0)
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				r.path(item, item.Value, 0)
+			}
+		}
+	}
+}
+
+// list processes comma separated paths of the scalar starting at the given offset of its value
+func (r *renamer) list(node *yaml.Node, list string, offset int) {
+	for _, item := range strings.Split(list, ",") {
+		trimmed := strings.TrimSpace(item)
+		r.path(node, trimmed, offset+strings.Index(item, trimmed))
+		offset += len(item) + 1
+	}
+}
+
+// path applies the replacer to the path located at the given offset of the scalar value. Prefixes like
+// github.com/user are matched as github.com/user/ too so that prefix rules work for them
+func (r *renamer) path(node *yaml.Node, path string, offset int) {
+	if path == "" || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return
+	}
+
+	var newPath string
+	if v, ok := r.rep.Replace(path).(replacer.Replacement); ok {
+		newPath = v.String()
+	} else if v, ok := r.rep.Replace(path + "/").(replacer.Replacement); ok {
+		newPath = strings.TrimSuffix(v.String(), "/")
+	} else {
+		return
+	}
+
+	start, ok := r.valueStart(node)
+	if !ok {
+		return
+	}
+	start += offset
+	if start+len(path) > len(r.data) || string(r.data[start:start+len(path)]) != path {
+		// escaped values can't be changed in place
+		return
+	}
+	r.edits = append(r.edits, textedit.NewEdit(r.data, start, start+len(path), newPath))
+}
+
+// valueStart returns an offset of the scalar value in data, quotes are skipped
+func (r *renamer) valueStart(node *yaml.Node) (int, bool) {
+	if node.Line < 1 || node.Line > len(r.lines) {
+		return 0, false
+	}
+	start := r.lines[node.Line-1]
+	line := r.data[start:]
+	if end := bytes.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+
+	// columns are counted in characters
+	var col int
+	for i := range string(line) {
+		col++
+		if col == node.Column {
+			start += i
+			if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+				start++
+			}
+			return start, true
+		}
+	}
+	return 0, false
+}
+
+func lineStarts(data []byte) []int {
+	res := []int{0}
+	for i, c := range data {
+		if c == '\n' {
+			res = append(res, i+1)
+		}
+	}
+	return res
+}
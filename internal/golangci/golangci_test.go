@@ -0,0 +1,137 @@
+package golangci
+
+import (
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+func TestRename(t *testing.T) {
+	rep := replacer.Prefix("github.com/org/", "github.com/neworg/")
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name: "linters-settings",
+			input: `# lint configuration
+linters-settings:
+  goimports:
+    local-prefixes: github.com/other,github.com/org # our code
+  gci:
+    sections:
+      - standard
+      - default
+      - prefix(github.com/org,github.com/other)
+  depguard:
+    rules:
+      main:
+        allow:
+          - $gostd
+          - "github.com/org/lib"
+        deny:
+          - pkg: 'github.com/org/deprecated'
+            desc: use github.com/org/lib instead
+  importas:
+    alias:
+      - pkg: github.com/org/lib/api/v1
+        alias: apiv1
+      - pkg: github.com/other/api
+        alias: otherapi
+issues:
+  exclude:
+    - github.com/org/lib
+`,
+			want: `# lint configuration
+linters-settings:
+  goimports:
+    local-prefixes: github.com/other,github.com/neworg # our code
+  gci:
+    sections:
+      - standard
+      - default
+      - prefix(github.com/neworg,github.com/other)
+  depguard:
+    rules:
+      main:
+        allow:
+          - $gostd
+          - "github.com/neworg/lib"
+        deny:
+          - pkg: 'github.com/neworg/deprecated'
+            desc: use github.com/org/lib instead
+  importas:
+    alias:
+      - pkg: github.com/neworg/lib/api/v1
+        alias: apiv1
+      - pkg: github.com/other/api
+        alias: otherapi
+issues:
+  exclude:
+    - github.com/org/lib
+`,
+		},
+		{
+			name: "v2-layout",
+			input: `version: "2"
+formatters:
+  settings:
+    goimports:
+      local-prefixes:
+        - github.com/org
+linters:
+  settings:
+    depguard:
+      rules:
+        main:
+          deny:
+            - pkg: github.com/org/deprecated
+`,
+			want: `version: "2"
+formatters:
+  settings:
+    goimports:
+      local-prefixes:
+        - github.com/neworg
+linters:
+  settings:
+    depguard:
+      rules:
+        main:
+          deny:
+            - pkg: github.com/neworg/deprecated
+`,
+		},
+		{
+			name: "depguard-v1",
+			input: `linters-settings:
+  depguard:
+    packages:
+      - github.com/org/bad
+    packages-with-error-message:
+      - github.com/org/worse: "do not use"
+`,
+			want: `linters-settings:
+  depguard:
+    packages:
+      - github.com/neworg/bad
+    packages-with-error-message:
+      - github.com/neworg/worse: "do not use"
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits, err := Rename([]byte(tt.input), rep)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(textedit.Apply([]byte(tt.input), edits)); got != tt.want {
+				t.Errorf("Rename() resulted in\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
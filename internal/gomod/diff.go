@@ -0,0 +1,205 @@
+package gomod
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/sirkon/go-imports-rename/internal/parser"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// Delta module path changes between two go.mod files
+type Delta struct {
+	// Rules turn module paths of the former go.mod into ones of the latter
+	Rules []parser.Rule
+	// Suggestions are rules for weakly related module paths, like ones of different repositories sharing a name,
+	// they are not to be applied without review
+	Suggestions []parser.Rule
+	// Removed module paths no match were found for
+	Removed []string
+	// Added module paths no match were found for
+	Added []string
+}
+
+// Diff infers module path renames and major version changes between two go.mod files. Module paths are matched
+// by their major version relationships first and by path similarity then. Paths of different repositories which
+// are only similar by name make suggestions rather than rules
+func Diff(before, after *modfile.File) Delta {
+	beforePaths := modulePaths(before)
+	afterPaths := modulePaths(after)
+
+	var removed, added []modulePath
+	for p := range beforePaths {
+		if _, ok := afterPaths[p]; !ok {
+			removed = append(removed, newModulePath(p))
+		}
+	}
+	for p := range afterPaths {
+		if _, ok := beforePaths[p]; !ok {
+			added = append(added, newModulePath(p))
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].path < removed[j].path })
+	sort.Slice(added, func(i, j int) bool { return added[i].path < added[j].path })
+
+	var res Delta
+	used := make([]bool, len(added))
+	for _, r := range removed {
+		best := -1
+		var bestScore int
+		for i, a := range added {
+			if used[i] {
+				continue
+			}
+			if score := matchScore(r, a); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			res.Removed = append(res.Removed, r.path)
+			continue
+		}
+
+		used[best] = true
+		if sameRepository(r, added[best]) {
+			res.Rules = append(res.Rules, deltaRule(r, added[best]))
+		} else {
+			res.Suggestions = append(res.Suggestions, deltaRule(r, added[best]))
+		}
+	}
+	for i, a := range added {
+		if !used[i] {
+			res.Added = append(res.Added, a.path)
+		}
+	}
+
+	return res
+}
+
+// modulePath module path with its versionless part and major version
+type modulePath struct {
+	path  string
+	root  string
+	major int
+	gopkg bool
+}
+
+func newModulePath(p string) modulePath {
+	root, major, err := replacer.CurrentMajor(p)
+	if err != nil {
+		return modulePath{path: p, root: p, major: 1}
+	}
+	return modulePath{
+		path:  p,
+		root:  root,
+		major: major,
+		gopkg: strings.HasPrefix(p, "gopkg.in/"),
+	}
+}
+
+// modulePaths returns the module path and paths of required modules
+func modulePaths(modFile *modfile.File) map[string]struct{} {
+	res := map[string]struct{}{}
+	if modFile.Module != nil {
+		res[modFile.Module.Mod.Path] = struct{}{}
+	}
+	for _, req := range modFile.Require {
+		res[req.Mod.Path] = struct{}{}
+	}
+	return res
+}
+
+// matchScore returns 0 for paths having nothing in common and greater values for closer paths
+func matchScore(r, a modulePath) int {
+	if r.root == a.root {
+		return 1000
+	}
+
+	rElems := strings.Split(r.root, "/")
+	aElems := strings.Split(a.root, "/")
+	if path.Base(r.root) != path.Base(a.root) {
+		return 0
+	}
+
+	score := 100
+	if sameRepository(r, a) {
+		score += 500
+	}
+	for i := 1; i <= len(rElems) && i <= len(aElems) && rElems[len(rElems)-i] == aElems[len(aElems)-i]; i++ {
+		score += 10
+	}
+	for i := 0; i < len(rElems) && i < len(aElems) && rElems[i] == aElems[i]; i++ {
+		score++
+	}
+	return score
+}
+
+// sameRepository checks if paths belong to the same repository, i.e. share host, owner and repository name.
+// gopkg.in paths are compared with GitHub repositories they are served from
+func sameRepository(r, a modulePath) bool {
+	for _, rRepo := range repositories(r) {
+		for _, aRepo := range repositories(a) {
+			if rRepo == aRepo {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// repositories returns host/owner/repo candidates of the module path:
+//
+//	github.com/user/project/sub => github.com/user/project
+//	gopkg.in/user/pkg.v2        => github.com/user/pkg
+//	gopkg.in/pkg.v2             => github.com/go-pkg/pkg, github.com/pkg/pkg
+func repositories(p modulePath) []string {
+	elems := strings.Split(p.root, "/")
+	if p.gopkg && len(elems) == 2 {
+		return []string{
+			"github.com/go-" + elems[1] + "/" + elems[1],
+			"github.com/" + elems[1] + "/" + elems[1],
+		}
+	}
+	if p.gopkg {
+		return []string{"github.com/" + elems[1] + "/" + elems[2]}
+	}
+	if len(elems) < 3 {
+		return []string{p.root}
+	}
+	return []string{strings.Join(elems[:3], "/")}
+}
+
+// deltaRule creates a rule turning r into a
+func deltaRule(r, a modulePath) parser.Rule {
+	switch {
+	case r.root == a.root && a.major > r.major:
+		return parser.Add{
+			Import: r.path,
+			Jump:   a.major - r.major,
+		}
+	case r.root == a.root && a.major == 1 && !r.gopkg:
+		return parser.Strip{
+			Import: r.path,
+		}
+	case r.root == a.root:
+		return parser.Sub{
+			Import: r.path,
+			Jump:   r.major - a.major,
+		}
+	case a.major > r.major:
+		return parser.Add{
+			Import: r.path,
+			Jump:   a.major - r.major,
+			To:     a.root,
+		}
+	default:
+		// module rules leave nested major version modules of suffixless paths alone
+		return parser.Module{
+			From: r.path,
+			To:   a.path,
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package gomod
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/sirkon/go-imports-rename/internal/parser"
+)
+
+func TestDiff(t *testing.T) {
+	before, err := modfile.Parse("before/go.mod", []byte(`module github.com/org/app
+
+go 1.18
+
+require (
+	github.com/org/lib v1.5.0
+	github.com/org/tool/v3 v3.1.0
+	github.com/upstream/fork v1.0.0
+	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/foo.v1 v1.0.0
+	github.com/org/common v1.0.0
+	github.com/org/gone v1.0.0
+	github.com/pkg/errors v0.9.1
+)
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := modfile.Parse("after/go.mod", []byte(`module github.com/org/app/v2
+
+go 1.18
+
+require (
+	github.com/org/lib/v3 v3.0.0
+	github.com/org/tool v1.9.0
+	github.com/ourfork/fork v1.0.1
+	gopkg.in/yaml.v3 v3.0.1
+	github.com/foo/foo/v2 v2.0.0
+	github.com/org/common v1.0.0
+	github.com/org/new v1.0.0
+	github.com/go-errors/errors v1.4.2
+)
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Delta{
+		Rules: []parser.Rule{
+			parser.Add{Import: "github.com/org/app", Jump: 1},
+			parser.Add{Import: "github.com/org/lib", Jump: 2},
+			parser.Strip{Import: "github.com/org/tool/v3"},
+			parser.Add{Import: "gopkg.in/foo.v1", Jump: 1, To: "github.com/foo/foo"},
+			parser.Add{Import: "gopkg.in/yaml.v2", Jump: 1},
+		},
+		Suggestions: []parser.Rule{
+			parser.Module{From: "github.com/pkg/errors", To: "github.com/go-errors/errors"},
+			parser.Module{From: "github.com/upstream/fork", To: "github.com/ourfork/fork"},
+		},
+		Removed: []string{"github.com/org/gone"},
+		Added:   []string{"github.com/org/new"},
+	}
+	if got := Diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %#v, want %#v", got, want)
+	}
+}
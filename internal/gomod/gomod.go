@@ -0,0 +1,66 @@
+package gomod
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// Read reads and parses go.mod file
+func Read(fileName string) (*modfile.File, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "read go.mod")
+	}
+	modFile, err := modfile.Parse(fileName, data, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse go.mod")
+	}
+	return modFile, nil
+}
+
+// Format formats go.mod file in a canonical way
+func Format(modFile *modfile.File) ([]byte, error) {
+	modFile.SortBlocks()
+	modFile.Cleanup()
+	data, err := modFile.Format()
+	if err != nil {
+		return nil, errors.WithMessage(err, "format go.mod")
+	}
+	return data, nil
+}
+
+// Write formats and saves go.mod file
+func Write(fileName string, modFile *modfile.File) error {
+	data, err := Format(modFile)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return errors.WithMessage(err, "stat go.mod")
+	}
+	if err := os.WriteFile(fileName, data, info.Mode()); err != nil {
+		return errors.WithMessage(err, "write go.mod")
+	}
+	return nil
+}
+
+// RenameRequires applies the replacer to module paths of go.mod require directives keeping versions intact
+func RenameRequires(modFile *modfile.File, rep replacer.Replacer) []PathChange {
+	var res []PathChange
+	for _, req := range modFile.Require {
+		v, ok := rep.Replace(req.Mod.Path).(replacer.Replacement)
+		if !ok {
+			continue
+		}
+		res = append(res, PathChange{Old: req.Mod.Path, New: v.String()})
+		replaceToken(req.Syntax, req.Mod.Path, v.String())
+		req.Mod.Path = v.String()
+	}
+	return res
+}
@@ -0,0 +1,137 @@
+package gomod
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+)
+
+const replacesGoMod = `module example.com/app
+
+go 1.18
+
+require (
+	github.com/upstream/x v1.0.0
+	github.com/upstream/y v1.0.0 // indirect
+	github.com/upstream/z v1.0.0
+)
+
+replace github.com/upstream/x => github.com/ourfork/x v1.2.3
+
+replace github.com/upstream/y v1.0.0 => github.com/ourfork/y v1.0.1
+
+replace github.com/upstream/z => ../z
+
+replace github.com/upstream/w => github.com/upstream/w v1.0.1
+`
+
+func TestPathReplaces(t *testing.T) {
+	modFile, err := modfile.Parse("go.mod", []byte(replacesGoMod), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Replace{
+		{
+			Old:        "github.com/upstream/x",
+			New:        "github.com/ourfork/x",
+			NewVersion: "v1.2.3",
+		},
+		{
+			Old:        "github.com/upstream/y",
+			OldVersion: "v1.0.0",
+			New:        "github.com/ourfork/y",
+			NewVersion: "v1.0.1",
+		},
+	}
+	if got := PathReplaces(modFile); !reflect.DeepEqual(got, want) {
+		t.Errorf("PathReplaces() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDropReplaces(t *testing.T) {
+	modFile, err := modfile.Parse("go.mod", []byte(replacesGoMod), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DropReplaces(modFile, PathReplaces(modFile)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := Format(modFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `module example.com/app
+
+go 1.18
+
+require (
+	github.com/ourfork/x v1.2.3
+	github.com/ourfork/y v1.0.1 // indirect
+	github.com/upstream/z v1.0.0
+)
+
+replace github.com/upstream/z => ../z
+
+replace github.com/upstream/w => github.com/upstream/w v1.0.1
+`
+	if got := string(data); got != want {
+		t.Errorf("DropReplaces() resulted in\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDropReplaces_Kept(t *testing.T) {
+	input := `module example.com/app
+
+go 1.18
+
+require (
+	github.com/ourfork/q v1.0.0
+	github.com/ourfork/x v1.3.0
+	github.com/upstream/q v1.0.0
+	github.com/upstream/x v1.0.0
+)
+
+replace github.com/upstream/q => github.com/ourfork/q v1.1.0
+
+replace github.com/upstream/x => github.com/ourfork/x v1.2.3
+
+replace github.com/upstream/y => github.com/ourfork/y v1.0.0
+`
+	modFile, err := modfile.Parse("go.mod", []byte(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reps := PathReplaces(modFile)
+	if Requirement(modFile, reps[2]) != nil {
+		t.Errorf("Requirement() found a requirement of %s", reps[2].Old)
+	}
+	if err := DropReplaces(modFile, reps); err != nil {
+		t.Fatal(err)
+	}
+	data, err := Format(modFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// upstream/y is not required, its replace matters to dependencies and is kept. Already required ourfork/x is
+	// not downgraded
+	want := `module example.com/app
+
+go 1.18
+
+require (
+	github.com/ourfork/q v1.1.0
+	github.com/ourfork/x v1.3.0
+)
+
+replace github.com/upstream/y => github.com/ourfork/y v1.0.0
+`
+	if got := string(data); got != want {
+		t.Errorf("DropReplaces() resulted in\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package gomod
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Replace module path replacement of a go.mod replace directive
+type Replace struct {
+	// Old replaced module path and version, the version is empty for replaces of all versions
+	Old        string
+	OldVersion string
+	// New replacement module path and version
+	New        string
+	NewVersion string
+}
+
+// PathReplaces returns module path replacements of go.mod replace directives. Filesystem path replaces and
+// replaces of module versions with another version of the same module are left alone
+func PathReplaces(modFile *modfile.File) []Replace {
+	var res []Replace
+	for _, rep := range modFile.Replace {
+		if modfile.IsDirectoryPath(rep.New.Path) || rep.New.Version == "" {
+			continue
+		}
+		if rep.Old.Path == rep.New.Path {
+			continue
+		}
+		res = append(res, Replace{
+			Old:        rep.Old.Path,
+			OldVersion: rep.Old.Version,
+			New:        rep.New.Path,
+			NewVersion: rep.New.Version,
+		})
+	}
+	return res
+}
+
+// DropReplaces deletes given replace directives and requires replacement modules instead of replaced ones.
+// Replaces of modules which are not required are kept, they matter to dependencies which would silently switch
+// back to replaced modules otherwise. Versions of replacement modules which are already required are never
+// lowered
+func DropReplaces(modFile *modfile.File, reps []Replace) error {
+	for _, rep := range reps {
+		req := Requirement(modFile, rep)
+		if req == nil {
+			continue
+		}
+		indirect := req.Indirect
+
+		if err := modFile.DropReplace(rep.Old, rep.OldVersion); err != nil {
+			return errors.WithMessagef(err, "drop replace of %s", rep.Old)
+		}
+		if err := modFile.DropRequire(rep.Old); err != nil {
+			return errors.WithMessagef(err, "drop requirement of %s", rep.Old)
+		}
+		if version, ok := requiredVersion(modFile, rep.New); ok {
+			if semver.Compare(rep.NewVersion, version) <= 0 {
+				continue
+			}
+			if err := modFile.AddRequire(rep.New, rep.NewVersion); err != nil {
+				return errors.WithMessagef(err, "require %s %s", rep.New, rep.NewVersion)
+			}
+			continue
+		}
+		modFile.AddNewRequire(rep.New, rep.NewVersion, indirect)
+	}
+
+	return nil
+}
+
+// Requirement returns a requirement of the module replaced by the replace directive, it is nil when the module
+// is not required
+func Requirement(modFile *modfile.File, rep Replace) *modfile.Require {
+	for _, req := range modFile.Require {
+		if req.Mod.Path != rep.Old {
+			continue
+		}
+		if rep.OldVersion != "" && req.Mod.Version != rep.OldVersion {
+			continue
+		}
+		return req
+	}
+	return nil
+}
+
+// requiredVersion returns a required version of the module
+func requiredVersion(modFile *modfile.File, modulePath string) (string, bool) {
+	for _, req := range modFile.Require {
+		if req.Mod.Path == modulePath {
+			return req.Mod.Version, true
+		}
+	}
+	return "", false
+}
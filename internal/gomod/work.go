@@ -0,0 +1,101 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// ReadWork reads and parses go.work file
+func ReadWork(fileName string) (*modfile.WorkFile, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "read go.work")
+	}
+	work, err := modfile.ParseWork(fileName, data, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse go.work")
+	}
+	return work, nil
+}
+
+// WriteWork saves go.work file
+func WriteWork(fileName string, work *modfile.WorkFile) error {
+	work.Cleanup()
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return errors.WithMessage(err, "stat go.work")
+	}
+	if err := os.WriteFile(fileName, modfile.Format(work.Syntax), info.Mode()); err != nil {
+		return errors.WithMessage(err, "write go.work")
+	}
+	return nil
+}
+
+// PathChange a module path change
+type PathChange struct {
+	Old string
+	New string
+}
+
+// RenameWorkReplaces applies the replacer to module paths of go.work replace directives. Filesystem paths are left
+// alone
+func RenameWorkReplaces(work *modfile.WorkFile, rep replacer.Replacer) []PathChange {
+	var res []PathChange
+	for _, r := range work.Replace {
+		if v, ok := rep.Replace(r.Old.Path).(replacer.Replacement); ok {
+			res = append(res, PathChange{Old: r.Old.Path, New: v.String()})
+			replaceToken(r.Syntax, r.Old.Path, v.String())
+			r.Old.Path = v.String()
+		}
+		if modfile.IsDirectoryPath(r.New.Path) {
+			continue
+		}
+		if v, ok := rep.Replace(r.New.Path).(replacer.Replacement); ok {
+			res = append(res, PathChange{Old: r.New.Path, New: v.String()})
+			replaceToken(r.Syntax, r.New.Path, v.String())
+			r.New.Path = v.String()
+		}
+	}
+	return res
+}
+
+// UsedModule a module of go.work use directive
+type UsedModule struct {
+	Dir  string
+	Path string
+}
+
+// UsedModules reads module paths of go.work use directives. The dir is a directory of go.work
+func UsedModules(dir string, work *modfile.WorkFile) ([]UsedModule, error) {
+	var res []UsedModule
+	for _, use := range work.Use {
+		useDir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(useDir) {
+			useDir = filepath.Join(dir, useDir)
+		}
+		data, err := os.ReadFile(filepath.Join(useDir, "go.mod"))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "read go.mod of %s", use.Path)
+		}
+		res = append(res, UsedModule{
+			Dir:  use.Path,
+			Path: modfile.ModulePath(data),
+		})
+	}
+	return res, nil
+}
+
+// replaceToken replaces the token of the line keeping the rest of the line intact
+func replaceToken(line *modfile.Line, old, new string) {
+	for i, token := range line.Token {
+		if token == old || token == modfile.AutoQuote(old) {
+			line.Token[i] = modfile.AutoQuote(new)
+			return
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+func TestRenameWorkReplaces(t *testing.T) {
+	work, err := modfile.ParseWork("go.work", []byte(`go 1.18
+
+use ./app
+
+replace (
+	github.com/upstream/x => github.com/user/project/x v1.0.0 // pinned fork
+	github.com/user/project/y => ../y
+)
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := RenameWorkReplaces(work, replacer.Prefix("github.com/user/project/", "github.com/org/project/"))
+	want := []PathChange{
+		{Old: "github.com/user/project/x", New: "github.com/org/project/x"},
+		{Old: "github.com/user/project/y", New: "github.com/org/project/y"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("RenameWorkReplaces() = %v, want %v", changes, want)
+	}
+
+	wantText := `go 1.18
+
+use ./app
+
+replace (
+	github.com/upstream/x => github.com/org/project/x v1.0.0 // pinned fork
+	github.com/org/project/y => ../y
+)
+`
+	if got := string(modfile.Format(work.Syntax)); got != wantText {
+		t.Errorf("RenameWorkReplaces() resulted in\n%s\nwant\n%s", got, wantText)
+	}
+}
+
+func TestUsedModules(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app", "go.mod"), []byte("module github.com/user/project/app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	work, err := modfile.ParseWork("go.work", []byte("go 1.18\n\nuse ./app\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := UsedModules(dir, work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []UsedModule{{Dir: "./app", Path: "github.com/user/project/app"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UsedModules() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package ignore
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Pattern a pattern with gitignore syntax
+type Pattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ParsePattern parses a line of an ignore file. It returns false for empty lines and comments
+func ParsePattern(line string) (Pattern, bool, error) {
+	line = strings.TrimRight(line, "\r")
+	if !strings.HasSuffix(line, `\ `) {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return Pattern{}, false, nil
+	}
+
+	var res Pattern
+	if strings.HasPrefix(line, "!") {
+		res.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		res.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return Pattern{}, false, nil
+	}
+
+	// patterns having a slash anywhere but at the end are relative to the ignore file directory, other ones match
+	// at any level
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	var buf strings.Builder
+	if anchored {
+		buf.WriteString("^")
+	} else {
+		buf.WriteString("(^|/)")
+	}
+	if err := globRegexp(&buf, line); err != nil {
+		return Pattern{}, false, errors.WithMessagef(err, "invalid pattern `%s`", line)
+	}
+	buf.WriteString("$")
+
+	re, err := regexp.Compile(buf.String())
+	if err != nil {
+		return Pattern{}, false, errors.WithMessagef(err, "invalid pattern `%s`", line)
+	}
+	res.re = re
+	return res, true, nil
+}
+
+// Match checks if the slash separated path relative to the ignore file directory matches the pattern
+func (p Pattern) Match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	return p.re.MatchString(rel)
+}
+
+// globRegexp translates glob into regular expression
+func globRegexp(buf *strings.Builder, glob string) error {
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if !strings.HasPrefix(glob[i:], "**") {
+				buf.WriteString("[^/]*")
+				continue
+			}
+			atStart := i == 0 || glob[i-1] == '/'
+			rest := glob[i+2:]
+			switch {
+			case atStart && strings.HasPrefix(rest, "/"):
+				// **/ matches zero or more directories
+				buf.WriteString("(.*/)?")
+				i += 2
+			case atStart && rest == "":
+				buf.WriteString(".*")
+				i++
+			default:
+				buf.WriteString("[^/]*")
+				i++
+			}
+		case '?':
+			buf.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return errors.New("unclosed [")
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			buf.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				buf.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return nil
+}
+
+// List patterns of an ignore file
+type List struct {
+	patterns []Pattern
+}
+
+// Parse parses ignore file contents
+func Parse(data string) (*List, error) {
+	var res List
+	for i, line := range strings.Split(data, "\n") {
+		pattern, ok, err := ParsePattern(line)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "line %d", i+1)
+		}
+		if ok {
+			res.patterns = append(res.patterns, pattern)
+		}
+	}
+	return &res, nil
+}
+
+// Match checks the slash separated path relative to the ignore file directory. The last matching pattern decides,
+// matched is false when no pattern matches the path
+func (l *List) Match(rel string, isDir bool) (ignored bool, matched bool) {
+	for _, p := range l.patterns {
+		if p.Match(rel, isDir) {
+			ignored = !p.negate
+			matched = true
+		}
+	}
+	return ignored, matched
+}
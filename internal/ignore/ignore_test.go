@@ -0,0 +1,59 @@
+package ignore
+
+import (
+	"testing"
+)
+
+func TestList_Match(t *testing.T) {
+	list, err := Parse(`# generated stuff
+*.pb.go
+!keep.pb.go
+/gen/
+build/
+docs/**/*.go
+**/fixtures
+a?c.go
+[xy].go
+\#hash.go
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rel         string
+		isDir       bool
+		wantIgnored bool
+		wantMatched bool
+	}{
+		{rel: "api/service.pb.go", wantIgnored: true, wantMatched: true},
+		{rel: "api/keep.pb.go", wantIgnored: false, wantMatched: true},
+		{rel: "gen", isDir: true, wantIgnored: true, wantMatched: true},
+		{rel: "sub/gen", isDir: true},
+		{rel: "gen"},
+		{rel: "sub/build", isDir: true, wantIgnored: true, wantMatched: true},
+		{rel: "docs/a.go", wantIgnored: true, wantMatched: true},
+		{rel: "docs/x/y/a.go", wantIgnored: true, wantMatched: true},
+		{rel: "a/b/fixtures", isDir: true, wantIgnored: true, wantMatched: true},
+		{rel: "abc.go", wantIgnored: true, wantMatched: true},
+		{rel: "abbc.go"},
+		{rel: "x.go", wantIgnored: true, wantMatched: true},
+		{rel: "z.go"},
+		{rel: "#hash.go", wantIgnored: true, wantMatched: true},
+		{rel: "main.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			ignored, matched := list.Match(tt.rel, tt.isDir)
+			if ignored != tt.wantIgnored || matched != tt.wantMatched {
+				t.Errorf("Match() = %v, %v, want %v, %v", ignored, matched, tt.wantIgnored, tt.wantMatched)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse("[abc.go"); err == nil {
+		t.Error("error expected on unclosed [")
+	}
+}
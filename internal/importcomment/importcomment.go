@@ -0,0 +1,86 @@
+package importcomment
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+)
+
+// commentRe matches import comments: // import "path" or /* import "path" */
+var commentRe = regexp.MustCompile(`^(//\s*|/\*\s*)import\s+"([^"]+)"(\s*\*/)?$`)
+
+// Comment an import comment of a package clause
+type Comment struct {
+	// Group a comment group the comment belongs to
+	Group *ast.CommentGroup
+	// Comment the comment itself
+	Comment *ast.Comment
+	// Path an import path of the comment
+	Path string
+}
+
+// Find looks for an import comment on the package clause line of the file. The file must be parsed with comments
+func Find(fset *token.FileSet, file *ast.File) (Comment, bool) {
+	line := fset.Position(file.Name.End()).Line
+	for _, group := range file.Comments {
+		if group.Pos() < file.Name.End() {
+			continue
+		}
+		if fset.Position(group.Pos()).Line != line {
+			break
+		}
+		for _, comment := range group.List {
+			path, ok := Parse(comment.Text)
+			if !ok {
+				break
+			}
+			return Comment{
+				Group:   group,
+				Comment: comment,
+				Path:    path,
+			}, true
+		}
+		break
+	}
+	return Comment{}, false
+}
+
+// Parse returns an import path of the import comment. It returns false for other comments
+func Parse(text string) (string, bool) {
+	match := commentRe.FindStringSubmatch(text)
+	if match == nil {
+		return "", false
+	}
+	return match[2], true
+}
+
+// Rewrite changes an import path of the import comment keeping its style
+func Rewrite(text string, path string) string {
+	match := commentRe.FindStringSubmatchIndex(text)
+	if match == nil {
+		return text
+	}
+	return text[:match[4]] + path + text[match[5]:]
+}
+
+// Strip removes the import comment from the file
+func Strip(file *ast.File, c Comment) {
+	var list []*ast.Comment
+	for _, comment := range c.Group.List {
+		if comment != c.Comment {
+			list = append(list, comment)
+		}
+	}
+	if len(list) > 0 {
+		c.Group.List = list
+		return
+	}
+
+	var groups []*ast.CommentGroup
+	for _, group := range file.Comments {
+		if group != c.Group {
+			groups = append(groups, group)
+		}
+	}
+	file.Comments = groups
+}
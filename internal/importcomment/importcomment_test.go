@@ -0,0 +1,112 @@
+package importcomment
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		path string
+		ok   bool
+	}{
+		{
+			name: "line-comment",
+			src:  "package foo // import \"github.com/user/foo\"\n",
+			path: "github.com/user/foo",
+			ok:   true,
+		},
+		{
+			name: "block-comment",
+			src:  "package foo /* import \"github.com/user/foo\" */\n",
+			path: "github.com/user/foo",
+			ok:   true,
+		},
+		{
+			name: "next-line",
+			src:  "package foo\n\n// import \"github.com/user/foo\"\n",
+		},
+		{
+			name: "other-comment",
+			src:  "package foo // the foo package\n",
+		},
+		{
+			name: "none",
+			src:  "// Package foo\npackage foo\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "foo.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := Find(fset, file)
+			if ok != tt.ok || got.Path != tt.path {
+				t.Errorf("Find() = %q, %v, want %q, %v", got.Path, ok, tt.path, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "line-comment",
+			text: `// import "github.com/user/foo"`,
+			want: `// import "github.com/org/foo"`,
+		},
+		{
+			name: "block-comment",
+			text: `/*import "github.com/user/foo"*/`,
+			want: `/*import "github.com/org/foo"*/`,
+		},
+		{
+			name: "not-import-comment",
+			text: `// foo`,
+			want: `// foo`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Rewrite(tt.text, "github.com/org/foo"); got != tt.want {
+				t.Errorf("Rewrite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	src := "// Package foo does things\npackage foo // import \"github.com/user/foo\"\n\n// Foo does things\nfunc Foo() {}\n"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "foo.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, ok := Find(fset, file)
+	if !ok {
+		t.Fatal("import comment not found")
+	}
+	Strip(file, comment)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		t.Fatal(err)
+	}
+	want := "// Package foo does things\npackage foo\n\n// Foo does things\nfunc Foo() {}\n"
+	if buf.String() != want {
+		t.Errorf("Strip() resulted in\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,36 @@
+package importpath
+
+import (
+	"strings"
+
+	"golang.org/x/mod/module"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// IsRemote checks if the value looks like an import path of a remote package, i.e. github.com/user/project, rather
+// than a standard library package, a relative path or a file name
+func IsRemote(value string) bool {
+	if module.CheckImportPath(value) != nil {
+		return false
+	}
+	first := strings.Split(value, "/")[0]
+	return strings.Contains(first, ".") && !strings.HasPrefix(first, ".") && !strings.HasSuffix(first, ".go")
+}
+
+// Replace applies the replacer to the value if it is a remote import path or a path@version spec. The version is
+// kept as is
+func Replace(value string, rep replacer.Replacer) (string, bool) {
+	path, version := value, ""
+	if pos := strings.IndexByte(value, '@'); pos >= 0 {
+		path, version = value[:pos], value[pos:]
+	}
+	if !IsRemote(path) {
+		return "", false
+	}
+	v, ok := rep.Replace(path).(replacer.Replacement)
+	if !ok {
+		return "", false
+	}
+	return v.String() + version, true
+}
@@ -0,0 +1,59 @@
+package importpath
+
+import (
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+func TestReplace(t *testing.T) {
+	rep := replacer.Prefix("github.com/org/", "github.com/neworg/")
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		ok    bool
+	}{
+		{
+			name:  "path",
+			value: "github.com/org/svc/handlers",
+			want:  "github.com/neworg/svc/handlers",
+			ok:    true,
+		},
+		{
+			name:  "path-version",
+			value: "github.com/org/tools/cmd/gen@v1.2.0",
+			want:  "github.com/neworg/tools/cmd/gen@v1.2.0",
+			ok:    true,
+		},
+		{
+			name:  "not-matching",
+			value: "github.com/other/svc",
+		},
+		{
+			name:  "text",
+			value: "see github.com/org/svc for details",
+		},
+		{
+			name:  "relative",
+			value: "./github.com/org/svc",
+		},
+		{
+			name:  "file",
+			value: "main.go",
+		},
+		{
+			name:  "std",
+			value: "net/http",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Replace(tt.value, rep)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Replace() = %q, %v, want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package linkname
+
+import (
+	"strings"
+)
+
+const prefix = "//go:linkname "
+
+// Directive a //go:linkname localname [importpath.name] directive
+type Directive struct {
+	Local string
+	// Path an import path of the target, empty when there is no target
+	Path string
+	// Name a name of the target in its package, it can be a method, i.e. (*T).M
+	Name string
+}
+
+// Target returns target of the directive
+func (d Directive) Target() string {
+	if d.Path == "" {
+		return ""
+	}
+	return escapePath(d.Path) + "." + d.Name
+}
+
+// String renders the directive as a comment
+func (d Directive) String() string {
+	if d.Path == "" {
+		return prefix + d.Local
+	}
+	return prefix + d.Local + " " + d.Target()
+}
+
+// Parse parses a //go:linkname comment. It returns false for other comments
+func Parse(comment string) (Directive, bool) {
+	if !strings.HasPrefix(comment, prefix) {
+		return Directive{}, false
+	}
+	fields := strings.Fields(strings.TrimPrefix(comment, prefix))
+	switch len(fields) {
+	case 1:
+		return Directive{Local: fields[0]}, true
+	case 2:
+	default:
+		return Directive{}, false
+	}
+
+	// the package name is the part of the last path element before the first dot
+	target := fields[1]
+	start := strings.LastIndexByte(target, '/') + 1
+	pos := strings.IndexByte(target[start:], '.')
+	if pos < 0 {
+		return Directive{}, false
+	}
+	return Directive{
+		Local: fields[0],
+		Path:  unescapePath(target[:start+pos]),
+		Name:  target[start+pos+1:],
+	}, true
+}
+
+// escapePath escapes dots of the last path element the way linker symbols have them, i.e. gopkg.in/yaml%2ev2
+func escapePath(path string) string {
+	start := strings.LastIndexByte(path, '/') + 1
+	return path[:start] + strings.ReplaceAll(path[start:], ".", "%2e")
+}
+
+func unescapePath(path string) string {
+	start := strings.LastIndexByte(path, '/') + 1
+	return path[:start] + strings.ReplaceAll(path[start:], "%2e", ".")
+}
@@ -0,0 +1,65 @@
+package linkname
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    Directive
+		ok      bool
+	}{
+		{
+			name:    "pull",
+			comment: "//go:linkname localFunc github.com/user/project/pkg.Func",
+			want:    Directive{Local: "localFunc", Path: "github.com/user/project/pkg", Name: "Func"},
+			ok:      true,
+		},
+		{
+			name:    "method",
+			comment: "//go:linkname method github.com/user/project/pkg.(*T).Method",
+			want:    Directive{Local: "method", Path: "github.com/user/project/pkg", Name: "(*T).Method"},
+			ok:      true,
+		},
+		{
+			name:    "dotted-path",
+			comment: "//go:linkname y gopkg.in/yaml%2ev2.Unmarshal",
+			want:    Directive{Local: "y", Path: "gopkg.in/yaml.v2", Name: "Unmarshal"},
+			ok:      true,
+		},
+		{
+			name:    "std",
+			comment: "//go:linkname nanotime runtime.nanotime",
+			want:    Directive{Local: "nanotime", Path: "runtime", Name: "nanotime"},
+			ok:      true,
+		},
+		{
+			name:    "push",
+			comment: "//go:linkname exported",
+			want:    Directive{Local: "exported"},
+			ok:      true,
+		},
+		{
+			name:    "no-package",
+			comment: "//go:linkname local target",
+		},
+		{
+			name:    "other",
+			comment: "//go:generate stringer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.comment)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Parse() = %#v, %v, want %#v, %v", got, ok, tt.want, tt.ok)
+			}
+			if ok && got.String() != tt.comment {
+				t.Errorf("String() = %q, want %q", got.String(), tt.comment)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package modcache
+
+import (
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/sirkon/go-imports-rename/internal/parser"
+)
+
+// Deprecation returns a deprecation message of the module taken from go.mod of its latest version available in
+// given sources, it is empty for modules which are not deprecated
+func Deprecation(sources []string, modulePath string) (string, error) {
+	versions, err := Versions(sources, modulePath)
+	if err != nil {
+		return "", err
+	}
+	version := latestVersion(versions)
+	if version == "" {
+		return "", nil
+	}
+
+	modFile, err := ModFile(sources, modulePath, version)
+	if err != nil {
+		return "", err
+	}
+	if modFile == nil || modFile.Module == nil {
+		return "", nil
+	}
+	return modFile.Module.Deprecated, nil
+}
+
+// DeprecationReplacement looks for a replacement module path in the deprecation message
+//
+//	use example.com/new instead => example.com/new
+//
+// Subpaths of the deprecated module other than its newer major versions and links to web pages of repositories,
+// like issues or pull requests, are not replacements
+func DeprecationReplacement(modulePath, message string) (string, bool) {
+	for _, word := range strings.Fields(message) {
+		candidate := strings.TrimRight(strings.Trim(word, "`'\"()[]<>"), ".,;:!?")
+		candidate = strings.Trim(candidate, "`'\"()[]<>")
+		if i := strings.IndexAny(candidate, "@?#"); i >= 0 {
+			candidate = candidate[:i]
+		}
+		candidate = strings.TrimPrefix(strings.TrimPrefix(candidate, "https://"), "http://")
+		candidate = strings.TrimPrefix(candidate, "pkg.go.dev/")
+		candidate = strings.TrimRight(candidate, "/")
+
+		if candidate == modulePath || !strings.Contains(candidate, "/") {
+			continue
+		}
+		if module.CheckPath(candidate) != nil {
+			continue
+		}
+		if isWebPage(candidate) {
+			continue
+		}
+		if strings.HasPrefix(candidate, modulePath+"/") {
+			prefix, pathMajor, ok := module.SplitPathVersion(candidate)
+			if !ok || prefix != modulePath || pathMajor == "" {
+				continue
+			}
+		}
+		return candidate, true
+	}
+	return "", false
+}
+
+// webPageElements path elements of repository web pages rather than of packages, i.e. github.com/user/repo/issues/12
+var webPageElements = []string{"issues", "pull", "pulls", "merge_requests", "blob", "tree", "commit", "commits",
+	"releases", "tags", "wiki", "compare", "actions", "-"}
+
+// isWebPage checks if the path looks like a link to a web page of a repository, such elements are looked for past
+// the repository root, i.e. past host/owner/repo
+func isWebPage(candidate string) bool {
+	elems := strings.Split(candidate, "/")
+	if len(elems) <= 3 {
+		return false
+	}
+	for _, elem := range elems[3:] {
+		for _, item := range webPageElements {
+			if elem == item {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DeprecationRule returns a rule moving imports of the deprecated module to the replacement found in the deprecation
+// message. The rule stops at module boundaries, so that imports of the replacement are not touched when it is a newer
+// major version of the module:
+//
+//	use github.com/foo/bar/v2 instead => github.com/foo/bar ==> github.com/foo/bar/v2
+func DeprecationRule(modulePath, message string) (parser.Module, bool) {
+	replacement, ok := DeprecationReplacement(modulePath, message)
+	if !ok {
+		return parser.Module{}, false
+	}
+	return parser.Module{
+		From: modulePath,
+		To:   replacement,
+	}, true
+}
+
+// latestVersion returns the latest release out of given versions or the latest pre-release if there are no releases
+func latestVersion(versions []string) string {
+	var release, prerelease string
+	for _, version := range versions {
+		if !semver.IsValid(version) {
+			continue
+		}
+		if semver.Prerelease(version) != "" {
+			if prerelease == "" || semver.Compare(version, prerelease) > 0 {
+				prerelease = version
+			}
+			continue
+		}
+		if release == "" || semver.Compare(version, release) > 0 {
+			release = version
+		}
+	}
+	if release != "" {
+		return release
+	}
+	return prerelease
+}
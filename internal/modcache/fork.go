@@ -0,0 +1,23 @@
+package modcache
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CheckFork checks if the version of a replacement module can be required directly instead of a replace directive.
+// It can only be when its go.mod is available in sources and declares the module's own path
+func CheckFork(sources []string, modulePath, version string) error {
+	modFile, err := ModFile(sources, modulePath, version)
+	if err != nil {
+		return err
+	}
+	if modFile == nil || modFile.Module == nil {
+		return errors.Errorf("go.mod of %s@%s was not found in %s", modulePath, version, strings.Join(sources, ", "))
+	}
+	if declared := modFile.Module.Mod.Path; declared != modulePath {
+		return errors.Errorf("%s@%s declares itself as %s, it cannot be required directly", modulePath, version, declared)
+	}
+	return nil
+}
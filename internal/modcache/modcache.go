@@ -0,0 +1,295 @@
+package modcache
+
+import (
+	"bufio"
+	"encoding/json"
+	"go/build"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// Sources returns directories having module download layout (<module>/@v/list): a download cache of GOMODCACHE
+// and file:// entries of GOPROXY
+func Sources() []string {
+	var res []string
+
+	env := goEnv("GOMODCACHE", "GOPROXY")
+	modCache := env["GOMODCACHE"]
+	if modCache == "" {
+		gopath := os.Getenv("GOPATH")
+		if gopath == "" {
+			gopath = build.Default.GOPATH
+		}
+		if list := filepath.SplitList(gopath); len(list) > 0 {
+			modCache = filepath.Join(list[0], "pkg", "mod")
+		}
+	}
+	if modCache != "" {
+		res = append(res, filepath.Join(modCache, "cache", "download"))
+	}
+
+	return append(res, ProxyDirs(env["GOPROXY"])...)
+}
+
+// goEnv returns values of go environment variables. They are asked from the go tool to respect values set with
+// go env -w, the process environment is used when the go tool fails
+func goEnv(names ...string) map[string]string {
+	res := map[string]string{}
+	out, err := exec.Command("go", append([]string{"env", "-json"}, names...)...).Output()
+	if err == nil && json.Unmarshal(out, &res) == nil {
+		return res
+	}
+
+	res = map[string]string{}
+	for _, name := range names {
+		res[name] = os.Getenv(name)
+	}
+	return res
+}
+
+// ProxyDirs returns directories of file:// entries of the given GOPROXY value
+func ProxyDirs(goproxy string) []string {
+	var res []string
+	for _, item := range strings.FieldsFunc(goproxy, func(r rune) bool { return r == ',' || r == '|' }) {
+		item = strings.TrimSpace(item)
+		if !strings.HasPrefix(item, "file://") {
+			continue
+		}
+		dir := filepath.FromSlash(strings.TrimPrefix(item, "file://"))
+		if dir == "" {
+			continue
+		}
+		res = append(res, dir)
+	}
+	return res
+}
+
+// Versions returns versions of the module available in given sources
+func Versions(sources []string, modulePath string) ([]string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "escape module path %s", modulePath)
+	}
+
+	seen := map[string]struct{}{}
+	var res []string
+	for _, source := range sources {
+		versions, err := sourceVersions(filepath.Join(source, filepath.FromSlash(escaped), "@v"))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "look for %s versions in %s", modulePath, source)
+		}
+		for _, version := range versions {
+			if _, ok := seen[version]; ok {
+				continue
+			}
+			seen[version] = struct{}{}
+			res = append(res, version)
+		}
+	}
+
+	return res, nil
+}
+
+// sourceVersions reads versions from @v/list or from @v/*.mod files when the list is missing
+func sourceVersions(dir string) ([]string, error) {
+	file, err := os.Open(filepath.Join(dir, "list"))
+	if err == nil {
+		defer file.Close()
+		var res []string
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) > 0 {
+				res = append(res, fields[0])
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, errors.WithMessage(err, "read versions list")
+		}
+		if len(res) > 0 {
+			return res, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, errors.WithMessage(err, "open versions list")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.WithMessage(err, "read versions directory")
+	}
+	var res []string
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".mod") {
+			continue
+		}
+		version, err := module.UnescapeVersion(strings.TrimSuffix(entry.Name(), ".mod"))
+		if err != nil {
+			continue
+		}
+		res = append(res, version)
+	}
+	return res, nil
+}
+
+// ModuleOf looks for a module the import path belongs to in given sources. It returns false if there's no such
+// module in there
+func ModuleOf(sources []string, importPath string) (string, bool, error) {
+	for modulePath := importPath; modulePath != "." && modulePath != ""; modulePath = path.Dir(modulePath) {
+		if module.CheckPath(modulePath) != nil {
+			continue
+		}
+		versions, err := Versions(sources, modulePath)
+		if err != nil {
+			return "", false, err
+		}
+		if len(versions) > 0 {
+			return modulePath, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// ModFile reads go.mod of the given module version from sources. It returns nil if there is no such go.mod there
+func ModFile(sources []string, modulePath, version string) (*modfile.File, error) {
+	escapedPath, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "escape module path %s", modulePath)
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "escape version %s", version)
+	}
+
+	for _, source := range sources {
+		fileName := filepath.Join(source, filepath.FromSlash(escapedPath), "@v", escapedVersion+".mod")
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.WithMessagef(err, "read go.mod of %s@%s", modulePath, version)
+		}
+
+		modFile, err := modfile.ParseLax(fileName, data, nil)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parse go.mod of %s@%s", modulePath, version)
+		}
+		return modFile, nil
+	}
+
+	return nil, nil
+}
+
+// LatestMajor returns the highest major version of the module available in given sources. The root is a module
+// path without major version, i.e. github.com/user/project or gopkg.in/yaml. Suffixless modules having v0 or v1
+// versions only are reported as 1.
+func LatestMajor(sources []string, root string) (int, error) {
+	root = strings.TrimRight(root, "/")
+	candidates, err := majorCandidates(sources, root)
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int
+	for _, candidate := range candidates {
+		if candidate.major <= latest {
+			continue
+		}
+		versions, err := Versions(sources, candidate.path)
+		if err != nil {
+			return 0, err
+		}
+		if hasMajorVersion(versions, candidate.major) {
+			latest = candidate.major
+		}
+	}
+
+	if latest == 0 {
+		return 0, errors.Errorf("no versions of %s found in %s", root, strings.Join(sources, ", "))
+	}
+	return latest, nil
+}
+
+type majorCandidate struct {
+	path  string
+	major int
+}
+
+// majorCandidates looks for module paths of all majors of the root present in sources
+func majorCandidates(sources []string, root string) ([]majorCandidate, error) {
+	gopkg := strings.HasPrefix(root, "gopkg.in/")
+
+	var dir, prefix string
+	if gopkg {
+		parent, name := path.Split(root)
+		dir, prefix = strings.TrimRight(parent, "/"), name+".v"
+	} else {
+		dir, prefix = root, "v"
+	}
+	escapedDir, err := module.EscapePath(dir)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "escape module path %s", dir)
+	}
+
+	var res []majorCandidate
+	if !gopkg {
+		res = append(res, majorCandidate{path: root, major: 1})
+	}
+	for _, source := range sources {
+		entries, err := os.ReadDir(filepath.Join(source, filepath.FromSlash(escapedDir)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.WithMessagef(err, "look for %s majors in %s", root, source)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+				continue
+			}
+			major, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), prefix))
+			if err != nil || (!gopkg && major < 2) {
+				continue
+			}
+			res = append(res, majorCandidate{
+				path:  dir + "/" + entry.Name(),
+				major: major,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// hasMajorVersion checks if there is a version of the given major. Suffixless modules are v0 or v1, +incompatible
+// versions are not counted
+func hasMajorVersion(versions []string, major int) bool {
+	for _, version := range versions {
+		if strings.HasSuffix(version, "+incompatible") {
+			continue
+		}
+		fields := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 2)
+		if len(fields) == 0 {
+			continue
+		}
+		versionMajor, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		if versionMajor == major || (major == 1 && versionMajor == 0) {
+			return true
+		}
+	}
+	return false
+}
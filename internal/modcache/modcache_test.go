@@ -0,0 +1,322 @@
+package modcache
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/parser"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		fileName := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLatestMajor(t *testing.T) {
+	cache := t.TempDir()
+	writeFiles(t, cache, map[string]string{
+		"github.com/user/project/@v/list":          "v1.0.0\nv1.2.0\n",
+		"github.com/user/project/v2/@v/list":       "v2.0.0\n",
+		"github.com/user/project/v4/@v/v4.1.0.mod": "module github.com/user/project/v4\n",
+		"github.com/user/project/v5/@v/list":       "",
+		"github.com/!user/!project/@v/list":        "v0.1.0\n",
+		"github.com/user/legacy/@v/list":           "v1.0.0\nv3.0.0+incompatible\n",
+		"gopkg.in/yaml.v2/@v/list":                 "v2.4.0\n",
+	})
+	proxy := t.TempDir()
+	writeFiles(t, proxy, map[string]string{
+		"gopkg.in/yaml.v3/@v/list": "v3.0.1\n",
+	})
+	sources := []string{cache, proxy}
+
+	tests := []struct {
+		root    string
+		want    int
+		wantErr bool
+	}{
+		{root: "github.com/user/project", want: 4},
+		{root: "github.com/User/Project", want: 1},
+		{root: "github.com/user/legacy", want: 1},
+		{root: "gopkg.in/yaml", want: 3},
+		{root: "github.com/user/missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.root, func(t *testing.T) {
+			got, err := LatestMajor(sources, tt.root)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LatestMajor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LatestMajor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyDirs(t *testing.T) {
+	got := ProxyDirs("https://proxy.golang.org,file:///var/proxy|file:///opt/proxy,direct")
+	want := []string{filepath.FromSlash("/var/proxy"), filepath.FromSlash("/opt/proxy")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProxyDirs() = %v, want %v", got, want)
+	}
+	if got := ProxyDirs("off"); len(got) != 0 {
+		t.Errorf("ProxyDirs() = %v, want nothing", strings.Join(got, ", "))
+	}
+}
+
+func TestSources(t *testing.T) {
+	dir := t.TempDir()
+	cache := filepath.Join(dir, "modcache")
+	proxy := filepath.Join(dir, "proxy")
+	goenv := filepath.Join(dir, "go.env")
+	content := "GOMODCACHE=" + cache + "\nGOPROXY=file://" + filepath.ToSlash(proxy) + ",direct\n"
+	if err := os.WriteFile(goenv, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// values set with go env -w are taken into account
+	t.Setenv("GOENV", goenv)
+	t.Setenv("GOMODCACHE", "")
+	t.Setenv("GOPROXY", "")
+
+	want := []string{filepath.Join(cache, "cache", "download"), proxy}
+	if got := Sources(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sources() = %v, want %v", got, want)
+	}
+}
+
+func TestModuleOf(t *testing.T) {
+	cache := t.TempDir()
+	writeFiles(t, cache, map[string]string{
+		"github.com/user/project/@v/list":    "v1.0.0\n",
+		"github.com/user/project/v2/@v/list": "v2.0.0\n",
+	})
+	sources := []string{cache}
+
+	tests := []struct {
+		importPath string
+		want       string
+		wantOK     bool
+	}{
+		{importPath: "github.com/user/project/sub/pkg", want: "github.com/user/project", wantOK: true},
+		{importPath: "github.com/user/project/v2/sub", want: "github.com/user/project/v2", wantOK: true},
+		{importPath: "github.com/user/project", want: "github.com/user/project", wantOK: true},
+		{importPath: "github.com/user/other", want: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.importPath, func(t *testing.T) {
+			got, ok, err := ModuleOf(sources, tt.importPath)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ModuleOf() got = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDeprecation(t *testing.T) {
+	cache := t.TempDir()
+	writeFiles(t, cache, map[string]string{
+		"github.com/user/old/@v/list":         "v1.0.0\nv1.1.0\nv1.2.0-rc.1\n",
+		"github.com/user/old/@v/v1.0.0.mod":   "module github.com/user/old\n",
+		"github.com/user/old/@v/v1.1.0.mod":   "// Deprecated: use github.com/user/new instead.\nmodule github.com/user/old\n",
+		"github.com/user/fresh/@v/list":       "v1.0.0\n",
+		"github.com/user/fresh/@v/v1.0.0.mod": "module github.com/user/fresh\n",
+	})
+	sources := []string{cache}
+
+	got, err := Deprecation(sources, "github.com/user/old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "use github.com/user/new instead."; got != want {
+		t.Errorf("Deprecation() = %q, want %q", got, want)
+	}
+
+	got, err = Deprecation(sources, "github.com/user/fresh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "" {
+		t.Errorf("Deprecation() = %q, want nothing", got)
+	}
+}
+
+func TestDeprecationReplacement(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+		wantOK  bool
+	}{
+		{
+			name:    "use-instead",
+			message: "use github.com/user/new instead.",
+			want:    "github.com/user/new",
+			wantOK:  true,
+		},
+		{
+			name:    "quoted",
+			message: "moved to `github.com/user/new/v2`",
+			want:    "github.com/user/new/v2",
+			wantOK:  true,
+		},
+		{
+			name:    "url",
+			message: "see https://github.com/user/new/.",
+			want:    "github.com/user/new",
+			wantOK:  true,
+		},
+		{
+			name:    "major-version",
+			message: "use github.com/user/old/v2 instead",
+			want:    "github.com/user/old/v2",
+			wantOK:  true,
+		},
+		{
+			name:    "issue-link",
+			message: "see https://github.com/user/old/issues/12 for details",
+			wantOK:  false,
+		},
+		{
+			name:    "pull-request-link",
+			message: "see https://github.com/user/new/pull/3 for details",
+			wantOK:  false,
+		},
+		{
+			name:    "blob-link",
+			message: "see https://github.com/user/old/blob/main/README.md",
+			wantOK:  false,
+		},
+		{
+			name:    "issue-link-then-replacement",
+			message: "see https://github.com/user/old/issues/12, use github.com/user/new instead",
+			want:    "github.com/user/new",
+			wantOK:  true,
+		},
+		{
+			name:    "repository-named-like-page",
+			message: "use github.com/user/tree instead",
+			want:    "github.com/user/tree",
+			wantOK:  true,
+		},
+		{
+			name:    "subpath",
+			message: "github.com/user/old/docs explains the migration",
+			wantOK:  false,
+		},
+		{
+			name:    "pkg-go-dev",
+			message: "use https://pkg.go.dev/github.com/user/new?tab=doc instead",
+			want:    "github.com/user/new",
+			wantOK:  true,
+		},
+		{
+			name:    "self-mention",
+			message: "github.com/user/old is not maintained anymore",
+			wantOK:  false,
+		},
+		{
+			name:    "no-path",
+			message: "this module is not maintained anymore",
+			wantOK:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DeprecationReplacement("github.com/user/old", tt.message)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("DeprecationReplacement() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDeprecationRule(t *testing.T) {
+	rule, ok := DeprecationRule("github.com/foo/bar", "use github.com/foo/bar/v2 instead")
+	if !ok {
+		t.Fatal("no rule for the deprecation message")
+	}
+	want := parser.Module{From: "github.com/foo/bar", To: "github.com/foo/bar/v2"}
+	if rule != want {
+		t.Fatalf("DeprecationRule() = %v, want %v", rule, want)
+	}
+
+	rep, err := replacer.Module(rule.From, rule.To)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for old, want := range map[string]replacer.Variant{
+		"github.com/foo/bar":      replacer.Replacement("github.com/foo/bar/v2"),
+		"github.com/foo/bar/y":    replacer.Replacement("github.com/foo/bar/v2/y"),
+		"github.com/foo/bar/v2":   replacer.Nothing{},
+		"github.com/foo/bar/v2/y": replacer.Nothing{},
+	} {
+		if got := rep.Replace(old); !reflect.DeepEqual(got, want) {
+			t.Errorf("Replace(%s) = %v, want %v", old, got, want)
+		}
+	}
+}
+
+func TestCheckFork(t *testing.T) {
+	source := t.TempDir()
+	writeFiles(t, source, map[string]string{
+		"github.com/ourfork/x/@v/v1.2.3.mod":  "module github.com/ourfork/x\n",
+		"github.com/ourfork/y/@v/v1.0.0.mod":  "module github.com/upstream/y\n",
+		"github.com/ourfork/z/@v/v1.0.0.info": "{}",
+	})
+	sources := []string{source}
+
+	tests := []struct {
+		name    string
+		path    string
+		version string
+		wantErr bool
+	}{
+		{
+			name:    "own-path",
+			path:    "github.com/ourfork/x",
+			version: "v1.2.3",
+		},
+		{
+			name:    "declares-upstream-path",
+			path:    "github.com/ourfork/y",
+			version: "v1.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "no-go.mod",
+			path:    "github.com/ourfork/z",
+			version: "v1.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "unknown-version",
+			path:    "github.com/ourfork/x",
+			version: "v1.3.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckFork(sources, tt.path, tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("CheckFork() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
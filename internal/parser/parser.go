@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
 var _ error = ParseError{}
@@ -38,22 +39,15 @@ func Parse(input string) (Rule, error) {
 
 	preOp := scanner.Copy()
 	operator, err := scanner.NextOperator()
-	bolds := []interface{}{
-		bold(operatorPrefix),
-		bold(operatorVersionIncrement),
-		bold(operatorVersionAdd),
-		bold(operatorRegexp),
-	}
 	if err != nil {
 		if err == io.EOF {
 			return nil, ParseError{
-				Report:  fmt.Sprintf("missing operator (one of %s, %s, %s or %s)", bolds...),
+				Report:  fmt.Sprintf("missing operator (one of %s)", operatorsList()),
 				Details: scanner.FancyIndicator(1, 2),
 			}
 		} else {
 			return nil, ParseError{
-				Report: fmt.Sprintf(
-					"operator expected (one of %s, %s, %s or %s)", bolds...),
+				Report:  fmt.Sprintf("operator expected (one of %s)", operatorsList()),
 				Details: scanner.FancyIndicator(2, 0),
 			}
 		}
@@ -62,16 +56,41 @@ func Parse(input string) (Rule, error) {
 	switch operator {
 	case operatorPrefix:
 		return processPrefix(scanner, piece1)
+	case operatorModule:
+		return processModule(scanner, piece1)
 	case operatorVersionIncrement:
-		if err := scanner.AtEnd(); err != nil {
-			return nil, unwantedData(err, scanner)
+		to, err := processVersionTarget(scanner)
+		if err != nil {
+			return nil, err
 		}
 		return Add{
 			Import: piece1,
 			Jump:   1,
+			To:     to,
 		}, nil
 	case operatorVersionAdd:
 		return processVersionAdd(scanner, piece1)
+	case operatorVersionDecrement:
+		if err := scanner.AtEnd(); err != nil {
+			return nil, unwantedData(err, scanner)
+		}
+		return Sub{
+			Import: piece1,
+			Jump:   1,
+		}, nil
+	case operatorVersionSub:
+		return processVersionSub(scanner, piece1)
+	case operatorVersionStrip:
+		if err := scanner.AtEnd(); err != nil {
+			return nil, unwantedData(err, scanner)
+		}
+		return Strip{
+			Import: piece1,
+		}, nil
+	case operatorGlob:
+		return processGlob(scanner, piece1)
+	case operatorSwap:
+		return processSwap(scanner, piece1)
 	case operatorRegexp:
 		piece2, err := scanner.NextString()
 		if err != nil {
@@ -124,29 +143,187 @@ func processPrefix(scanner *Scanner, from string) (Rule, error) {
 	}, nil
 }
 
+func processModule(scanner *Scanner, from string) (Rule, error) {
+	piece2, err := scanner.NextString()
+	if err != nil {
+		if err == io.EOF {
+			return nil, ParseError{
+				Report:  "missing new module path",
+				Details: scanner.FancyIndicator(1, 25),
+			}
+		}
+		return nil, ParseError{
+			Report:  err.Error(),
+			Details: scanner.FancyIndicator(1, 0),
+		}
+	}
+	if err := scanner.AtEnd(); err != nil {
+		return nil, unwantedData(err, scanner)
+	}
+	return Module{
+		From: from,
+		To:   piece2,
+	}, nil
+}
+
+func processGlob(scanner *Scanner, from string) (Rule, error) {
+	piece2, err := scanner.NextString()
+	if err != nil {
+		if err == io.EOF {
+			return nil, ParseError{
+				Report:  "missing replacement pattern",
+				Details: scanner.FancyIndicator(1, 25),
+			}
+		}
+		return nil, ParseError{
+			Report:  err.Error(),
+			Details: scanner.FancyIndicator(1, 0),
+		}
+	}
+	if err := scanner.AtEnd(); err != nil {
+		return nil, unwantedData(err, scanner)
+	}
+	return Glob{
+		From: from,
+		To:   piece2,
+	}, nil
+}
+
+func processSwap(scanner *Scanner, first string) (Rule, error) {
+	piece2, err := scanner.NextString()
+	if err != nil {
+		if err == io.EOF {
+			return nil, ParseError{
+				Report:  "missing import path to swap with",
+				Details: scanner.FancyIndicator(1, 25),
+			}
+		}
+		return nil, ParseError{
+			Report:  err.Error(),
+			Details: scanner.FancyIndicator(1, 0),
+		}
+	}
+	if err := scanner.AtEnd(); err != nil {
+		return nil, unwantedData(err, scanner)
+	}
+	return Swap{
+		First:  first,
+		Second: piece2,
+	}, nil
+}
+
 func processVersionAdd(scanner *Scanner, piece1 string) (Rule, error) {
+	keyword := scanner.Copy()
+	if word, err := keyword.NextString(); err == nil && word == keywordLatest {
+		*scanner = *keyword
+		to, err := processVersionTarget(scanner)
+		if err != nil {
+			return nil, err
+		}
+		return Add{
+			Import: piece1,
+			To:     to,
+			Latest: true,
+		}, nil
+	}
+
+	jump, err := scanner.NextInt()
+	if err != nil {
+		if err == io.EOF {
+			return nil, ParseError{
+				Report:  fmt.Sprintf("missing version jump value (a number or %s)", bold(keywordLatest)),
+				Details: scanner.FancyIndicator(1, 4),
+			}
+		}
+		return nil, ParseError{
+			Report:  fmt.Sprintf("version jump value expected (a number or %s)", bold(keywordLatest)),
+			Details: scanner.FancyIndicator(1, 0),
+		}
+	}
+	to, err := processVersionTarget(scanner)
+	if err != nil {
+		return nil, err
+	}
+	return Add{
+		Import: piece1,
+		Jump:   jump,
+		To:     to,
+	}, nil
+}
+
+// processVersionTarget processes optional `=> new/module/path` part of version jump rules
+func processVersionTarget(scanner *Scanner) (string, error) {
+	if err := scanner.AtEnd(); err == nil {
+		return "", nil
+	}
+
+	preOp := scanner.Copy()
+	operator, err := scanner.NextOperator()
+	if err != nil || operator != operatorPrefix {
+		return "", ParseError{
+			Report:  fmt.Sprintf("unexpected data, either nothing or %s expected", bold(operatorPrefix)),
+			Details: preOp.FancyIndicator(100000000, 0),
+		}
+	}
+
+	to, err := scanner.NextString()
+	if err != nil {
+		if err == io.EOF {
+			return "", ParseError{
+				Report:  "missing new module path",
+				Details: scanner.FancyIndicator(1, 25),
+			}
+		}
+		return "", ParseError{
+			Report:  err.Error(),
+			Details: scanner.FancyIndicator(1, 0),
+		}
+	}
+	if err := scanner.AtEnd(); err != nil {
+		return "", unwantedData(err, scanner)
+	}
+	return to, nil
+}
+
+func processVersionSub(scanner *Scanner, piece1 string) (Rule, error) {
 	jump, err := scanner.NextInt()
 	if err != nil {
 		if err == io.EOF {
 			return nil, ParseError{
-				Report:  "missing version jump value",
+				Report:  "missing version drop value",
 				Details: scanner.FancyIndicator(1, 4),
 			}
 		}
 		return nil, ParseError{
-			Report:  "version jump value expected",
+			Report:  "version drop value expected",
 			Details: scanner.FancyIndicator(1, 0),
 		}
 	}
 	if err := scanner.AtEnd(); err != nil {
 		return nil, unwantedData(err, scanner)
 	}
-	return Add{
+	return Sub{
 		Import: piece1,
 		Jump:   jump,
 	}, nil
 }
 
+// operatorsList returns a human readable list of supported operators
+func operatorsList() string {
+	var buf strings.Builder
+	for i, op := range operators {
+		switch {
+		case i == 0:
+		case i == len(operators)-1:
+			buf.WriteString(" or ")
+		default:
+			buf.WriteString(", ")
+		}
+		buf.WriteString(bold(op))
+	}
+	return buf.String()
+}
+
 func unwantedData(err error, scanner *Scanner) ParseError {
 	return ParseError{
 		Report:  err.Error(),
@@ -24,6 +24,20 @@ func TestParse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "module",
+			args: "github.com/upstream/x ==> github.com/fork/x",
+			want: Module{
+				From: "github.com/upstream/x",
+				To:   "github.com/fork/x",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "module-no-target",
+			args:    "github.com/upstream/x ==>",
+			wantErr: true,
+		},
 		{
 			name: "increment",
 			args: "github.com/sirkon/ldetool ++",
@@ -42,6 +56,61 @@ func TestParse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "increment-move",
+			args: "gopkg.in/yaml.v2 ++ => github.com/go-yaml/yaml",
+			want: Add{
+				Import: "gopkg.in/yaml.v2",
+				Jump:   1,
+				To:     "github.com/go-yaml/yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "add-move",
+			args: "gopkg.in/yaml.v1 += 2 => github.com/go-yaml/yaml",
+			want: Add{
+				Import: "gopkg.in/yaml.v1",
+				Jump:   2,
+				To:     "github.com/go-yaml/yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "add-latest",
+			args: "github.com/sirkon/ldetool += latest",
+			want: Add{
+				Import: "github.com/sirkon/ldetool",
+				Latest: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "decrement",
+			args: "github.com/sirkon/ldetool/v3 --",
+			want: Sub{
+				Import: "github.com/sirkon/ldetool/v3",
+				Jump:   1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "sub",
+			args: "github.com/sirkon/ldetool/v5 -= 2",
+			want: Sub{
+				Import: "github.com/sirkon/ldetool/v5",
+				Jump:   2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "strip",
+			args: "github.com/sirkon/ldetool/v5 -/",
+			want: Strip{
+				Import: "github.com/sirkon/ldetool/v5",
+			},
+			wantErr: false,
+		},
 		{
 			name: "regexp",
 			args: "github.com/sirkon/([^/]*)/(.*) // github/com/sirkon/ldetool/$2",
@@ -51,6 +120,24 @@ func TestParse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "glob",
+			args: "github.com/org/*/proto/** ~> gitlab.example.com/proto/$1/$2",
+			want: Glob{
+				From: "github.com/org/*/proto/**",
+				To:   "gitlab.example.com/proto/$1/$2",
+			},
+			wantErr: false,
+		},
+		{
+			name: "swap",
+			args: "github.com/org/x <=> github.com/org/y",
+			want: Swap{
+				First:  "github.com/org/x",
+				Second: "github.com/org/y",
+			},
+			wantErr: false,
+		},
 		{
 			name:    "invalid-empty",
 			args:    "",
@@ -65,7 +152,7 @@ func TestParse(t *testing.T) {
 		},
 		{
 			name:    "invalid-invalid-operator",
-			args:    "import/path --",
+			args:    "import/path ??",
 			want:    nil,
 			wantErr: true,
 		},
@@ -87,6 +174,18 @@ func TestParse(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "invalid-increment-no-target",
+			args:    "import/path ++ =>",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid-increment-target-unwanted-data",
+			args:    "import/path ++ => path unwanted",
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "invalid-add-no-jump",
 			args:    "import/path += ",
@@ -99,12 +198,48 @@ func TestParse(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "invalid-add-latest-unwanted",
+			args:    "import/path += latest unwanted",
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "invalid-add-jump-unwanted",
 			args:    "import/path += 4 unwanted",
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "invalid-glob-no-replacement",
+			args:    "import/* ~> ",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid-glob-unwanted-data",
+			args:    "import/* ~> path/$1 unwanted",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid-swap-no-second",
+			args:    "import/path <=>",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid-sub-no-jump",
+			args:    "import/path/v3 -= ",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid-strip-unwanted-data",
+			args:    "import/path/v3 -/ v2",
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "invalid-regexp-no-replacement",
 			args:    "import/path => ",
@@ -134,3 +269,64 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestRule_String(t *testing.T) {
+	rules := []string{
+		"github.com/sirkon/ldetool/ => github.com/sirkon/ldetool/v2/",
+		"github.com/upstream/x ==> github.com/fork/x",
+		"github.com/sirkon/ldetool ++",
+		"github.com/sirkon/ldetool += 5",
+		"github.com/sirkon/ldetool += latest",
+		"gopkg.in/yaml.v2 ++ => github.com/go-yaml/yaml",
+		"github.com/sirkon/ldetool/v3 --",
+		"github.com/sirkon/ldetool/v5 -= 2",
+		"github.com/sirkon/ldetool/v5 -/",
+		"github.com/sirkon/([^/]*)/(.*) // github/com/sirkon/ldetool/$2",
+		`^gen/(.*)\ (.*)$ // gen/$1`,
+		"github.com/org/*/proto/** ~> gitlab.example.com/proto/$1/$2",
+		"github.com/org/x <=> github.com/org/y",
+	}
+	for _, input := range rules {
+		t.Run(input, func(t *testing.T) {
+			rule, err := Parse(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rule.String(); got != input {
+				t.Errorf("String() = %s, want %s", got, input)
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	input := `
+# upgrades
+github.com/sirkon/ldetool ++
+
+github.com/org/x <=> github.com/org/y
+`
+	want := []Rule{
+		Add{
+			Import: "github.com/sirkon/ldetool",
+			Jump:   1,
+		},
+		Swap{
+			First:  "github.com/org/x",
+			Second: "github.com/org/y",
+		},
+	}
+	got, err := ParseRules(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseRules() got = %v, want %v", got, want)
+	}
+
+	if _, err := ParseRules("github.com/sirkon/ldetool ++\nimport/path"); err == nil {
+		t.Error("ParseRules() error expected")
+	} else if v, ok := err.(ParseError); !ok || v.Report[:7] != "line 2:" {
+		t.Errorf("ParseRules() unexpected error %v", err)
+	}
+}
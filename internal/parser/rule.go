@@ -1,7 +1,13 @@
 package parser
 
-// Rule abstract rule description
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule abstract rule description, its String method returns the rule in the rule language
 type Rule interface {
+	fmt.Stringer
 	rule()
 }
 
@@ -15,16 +21,53 @@ type Prefix struct {
 
 func (Prefix) rule() {}
 
+func (r Prefix) String() string {
+	return escape(r.From) + " " + operatorPrefix + " " + escape(r.To)
+}
+
+var _ Rule = Module{}
+
+// Module module path change rule description, nested major version modules of From are not affected
+type Module struct {
+	From string
+	To   string
+}
+
+func (Module) rule() {}
+
+func (r Module) String() string {
+	return escape(r.From) + " " + operatorModule + " " + escape(r.To)
+}
+
 var _ Rule = Add{}
 
-// Add (and increment) rule description
+// Add (and increment) rule description. To is a new module path for the migration, empty when the module stays
+// where it is. Latest is set when the jump must be resolved to the highest available major version
 type Add struct {
 	Import string
 	Jump   int
+	To     string
+	Latest bool
 }
 
 func (Add) rule() {}
 
+func (r Add) String() string {
+	var res string
+	switch {
+	case r.Latest:
+		res = escape(r.Import) + " " + operatorVersionAdd + " " + keywordLatest
+	case r.Jump == 1:
+		res = escape(r.Import) + " " + operatorVersionIncrement
+	default:
+		res = fmt.Sprintf("%s %s %d", escape(r.Import), operatorVersionAdd, r.Jump)
+	}
+	if r.To != "" {
+		res += " " + operatorPrefix + " " + escape(r.To)
+	}
+	return res
+}
+
 var _ Rule = Regexp{}
 
 // Regexp rule description
@@ -34,3 +77,70 @@ type Regexp struct {
 }
 
 func (Regexp) rule() {}
+
+func (r Regexp) String() string {
+	return escape(r.From) + " " + operatorRegexp + " " + escape(r.To)
+}
+
+var _ Rule = Glob{}
+
+// Glob path segment glob rule description
+type Glob struct {
+	From string
+	To   string
+}
+
+func (Glob) rule() {}
+
+func (r Glob) String() string {
+	return escape(r.From) + " " + operatorGlob + " " + escape(r.To)
+}
+
+var _ Rule = Swap{}
+
+// Swap rule description
+type Swap struct {
+	First  string
+	Second string
+}
+
+func (Swap) rule() {}
+
+func (r Swap) String() string {
+	return escape(r.First) + " " + operatorSwap + " " + escape(r.Second)
+}
+
+var _ Rule = Sub{}
+
+// Sub (and decrement) rule description
+type Sub struct {
+	Import string
+	Jump   int
+}
+
+func (Sub) rule() {}
+
+func (r Sub) String() string {
+	if r.Jump == 1 {
+		return escape(r.Import) + " " + operatorVersionDecrement
+	}
+	return fmt.Sprintf("%s %s %d", escape(r.Import), operatorVersionSub, r.Jump)
+}
+
+var _ Rule = Strip{}
+
+// Strip major version suffix removal rule description
+type Strip struct {
+	Import string
+}
+
+func (Strip) rule() {}
+
+func (r Strip) String() string {
+	return escape(r.Import) + " " + operatorVersionStrip
+}
+
+// escape escapes spaces the way the scanner expects them
+func escape(s string) string {
+	return strings.ReplaceAll(s, " ", `\ `)
+}
@@ -0,0 +1,30 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseRules parses rules file contents: one rule per line, empty lines and lines starting with # are ignored
+func ParseRules(input string) ([]Rule, error) {
+	var res []Rule
+	for i, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := Parse(line)
+		if err != nil {
+			if v, ok := err.(ParseError); ok {
+				return nil, ParseError{
+					Report:  fmt.Sprintf("line %d: %s", i+1, v.Report),
+					Details: v.Details,
+				}
+			}
+			return nil, fmt.Errorf("line %d: %s", i+1, err)
+		}
+		res = append(res, rule)
+	}
+	return res, nil
+}
@@ -11,11 +11,34 @@ import (
 
 const (
 	operatorPrefix           = "=>"
+	operatorModule           = "==>"
 	operatorVersionIncrement = "++"
 	operatorVersionAdd       = "+="
 	operatorRegexp           = "//"
+	operatorGlob             = "~>"
+	operatorSwap             = "<=>"
+	operatorVersionDecrement = "--"
+	operatorVersionSub       = "-="
+	operatorVersionStrip     = "-/"
 )
 
+// keywordLatest a version jump to the latest available major version
+const keywordLatest = "latest"
+
+// operators all supported operators
+var operators = []string{
+	operatorPrefix,
+	operatorModule,
+	operatorVersionIncrement,
+	operatorVersionAdd,
+	operatorRegexp,
+	operatorGlob,
+	operatorSwap,
+	operatorVersionDecrement,
+	operatorVersionSub,
+	operatorVersionStrip,
+}
+
 // Scanner input scanner
 type Scanner struct {
 	orig []rune
@@ -81,7 +104,7 @@ func (operatorExpected) Error() string {
 	return "operator expected"
 }
 
-// NextOperator returns operator (one of =>, ++, += and //)
+// NextOperator returns operator (one of =>, ++, +=, //, ~>, <=>, --, -= and -/)
 func (s *Scanner) NextOperator() (string, error) {
 	s.trimSpaces()
 
@@ -90,19 +113,17 @@ func (s *Scanner) NextOperator() (string, error) {
 	}
 
 	rest := string(s.rest)
-	if len(rest) < 2 {
-		return "", operatorExpected{}
+	var piece string
+	for _, op := range operators {
+		// operators may share a prefix, the longest one wins
+		if strings.HasPrefix(rest, op) && len(op) > len(piece) {
+			piece = op
+		}
 	}
-	piece := rest[:2]
-	switch piece {
-	case operatorPrefix:
-	case operatorVersionIncrement:
-	case operatorVersionAdd:
-	case operatorRegexp:
-	default:
+	if piece == "" {
 		return "", operatorExpected{}
 	}
-	s.rest = s.rest[2:]
+	s.rest = s.rest[len([]rune(piece)):]
 
 	return piece, nil
 }
@@ -63,6 +63,12 @@ func TestScanner_NextOperator(t *testing.T) {
 			want:    "=>",
 			wantErr: false,
 		},
+		{
+			name:    "operator-module",
+			scanner: NewScanner(" ==> a/y"),
+			want:    "==>",
+			wantErr: false,
+		},
 		{
 			name:    "operator-increment",
 			scanner: NewScanner(" ++"),
@@ -81,6 +87,30 @@ func TestScanner_NextOperator(t *testing.T) {
 			want:    "//",
 			wantErr: false,
 		},
+		{
+			name:    "operator-glob",
+			scanner: NewScanner(" ~>"),
+			want:    "~>",
+			wantErr: false,
+		},
+		{
+			name:    "operator-swap",
+			scanner: NewScanner("<=> a/y"),
+			want:    "<=>",
+			wantErr: false,
+		},
+		{
+			name:    "operator-decrement",
+			scanner: NewScanner("--"),
+			want:    "--",
+			wantErr: false,
+		},
+		{
+			name:    "operator-strip",
+			scanner: NewScanner(" -/"),
+			want:    "-/",
+			wantErr: false,
+		},
 		{
 			name:    "no-operator",
 			scanner: NewScanner("abdef"),
@@ -0,0 +1,131 @@
+package proto
+
+import (
+	"path"
+	"strings"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenPunct
+)
+
+// token a token of a proto file, value of strings is their content without quotes
+type token struct {
+	kind  tokenKind
+	value string
+	start int
+}
+
+// tokenize splits proto source into tokens skipping spaces and comments
+func tokenize(data []byte) []token {
+	var res []token
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(string(data[i+2:]), "*/")
+			if end < 0 {
+				return res
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(data) && data[j] != c && data[j] != '\n' {
+				if data[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j > len(data) {
+				j = len(data)
+			}
+			res = append(res, token{kind: tokenString, value: string(data[i+1 : j]), start: i + 1})
+			i = j + 1
+		case isIdentChar(c):
+			j := i
+			for j < len(data) && (isIdentChar(data[j]) || data[j] == '.') {
+				j++
+			}
+			res = append(res, token{kind: tokenIdent, value: string(data[i:j]), start: i})
+			i = j
+		default:
+			res = append(res, token{kind: tokenPunct, value: string(c), start: i})
+			i++
+		}
+	}
+	return res
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// Rename applies the replacer to the path part of go_package options keeping the ;name suffix. Directories of
+// import statements are renamed too if imports is set, as proto paths mirror Go ones there
+func Rename(data []byte, rep replacer.Replacer, imports bool) []textedit.Edit {
+	var res []textedit.Edit
+	tokens := tokenize(data)
+	for i, tok := range tokens {
+		if tok.kind != tokenIdent {
+			continue
+		}
+
+		switch tok.value {
+		case "option":
+			// option go_package = "path;name";
+			if !match(tokens[i+1:], tokenIdent, "go_package") || !match(tokens[i+2:], tokenPunct, "=") ||
+				!match(tokens[i+3:], tokenString, "") {
+				continue
+			}
+			value := tokens[i+3]
+			goPath := strings.SplitN(value.value, ";", 2)[0]
+			if v, ok := rep.Replace(goPath).(replacer.Replacement); ok {
+				res = append(res, textedit.NewEdit(data, value.start, value.start+len(goPath), v.String()))
+			}
+
+		case "import":
+			// import [public|weak] "path/file.proto";
+			if !imports || (i > 0 && !match(tokens[i-1:], tokenPunct, ";") && !match(tokens[i-1:], tokenPunct, "}")) {
+				continue
+			}
+			j := i + 1
+			if match(tokens[j:], tokenIdent, "public") || match(tokens[j:], tokenIdent, "weak") {
+				j++
+			}
+			if !match(tokens[j:], tokenString, "") {
+				continue
+			}
+			value := tokens[j]
+			dir := path.Dir(value.value)
+			if dir == "." {
+				continue
+			}
+			if v, ok := rep.Replace(dir).(replacer.Replacement); ok {
+				res = append(res, textedit.NewEdit(data, value.start, value.start+len(dir), v.String()))
+			}
+		}
+	}
+	return res
+}
+
+// match checks if the first of tokens is of the given kind and value, any value of the kind is matched if the
+// value is empty
+func match(tokens []token, kind tokenKind, value string) bool {
+	if len(tokens) == 0 || tokens[0].kind != kind {
+		return false
+	}
+	return value == "" || tokens[0].value == value
+}
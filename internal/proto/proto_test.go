@@ -0,0 +1,87 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+func TestRename(t *testing.T) {
+	input := `syntax = "proto3";
+
+package common;
+
+// option go_package = "gen/common;common";
+import "gen/common/types.proto";
+import public "google/protobuf/empty.proto";
+import 'gen/other/other.proto';
+
+option go_package = "gen/common;common";
+option java_package = "gen/common";
+
+message Msg {
+  string import = 1; /* import "gen/common/x.proto"; */
+}
+`
+	rep := replacer.Chain(
+		replacer.Prefix("gen/", "gitlab.example.com/common/schema/"),
+		replacer.Prefix("google/protobuf", "github.com/protocolbuffers/protobuf"),
+	)
+
+	tests := []struct {
+		name    string
+		imports bool
+		want    string
+	}{
+		{
+			name:    "go-package",
+			imports: false,
+			want: `syntax = "proto3";
+
+package common;
+
+// option go_package = "gen/common;common";
+import "gen/common/types.proto";
+import public "google/protobuf/empty.proto";
+import 'gen/other/other.proto';
+
+option go_package = "gitlab.example.com/common/schema/common;common";
+option java_package = "gen/common";
+
+message Msg {
+  string import = 1; /* import "gen/common/x.proto"; */
+}
+`,
+		},
+		{
+			name:    "imports",
+			imports: true,
+			want: `syntax = "proto3";
+
+package common;
+
+// option go_package = "gen/common;common";
+import "gitlab.example.com/common/schema/common/types.proto";
+import public "github.com/protocolbuffers/protobuf/empty.proto";
+import 'gitlab.example.com/common/schema/other/other.proto';
+
+option go_package = "gitlab.example.com/common/schema/common;common";
+option java_package = "gen/common";
+
+message Msg {
+  string import = 1; /* import "gen/common/x.proto"; */
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := Rename([]byte(input), rep, tt.imports)
+			if got := string(textedit.Apply([]byte(input), edits)); got != tt.want {
+				t.Errorf("Rename() resulted in\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package replacer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ElementKind kind of import path element
+type ElementKind int
+
+const (
+	// ElementOrdinary ordinary path element, i.e. project or vendorx
+	ElementOrdinary ElementKind = iota
+	// ElementMajor module major version suffix, i.e. v2
+	ElementMajor
+	// ElementAPIVersion API version package, i.e. v1 or v1beta1 like in k8s.io/api/apps/v1
+	ElementAPIVersion
+)
+
+func (k ElementKind) String() string {
+	switch k {
+	case ElementOrdinary:
+		return "ordinary"
+	case ElementMajor:
+		return "major version suffix"
+	case ElementAPIVersion:
+		return "API version"
+	default:
+		return "ElementKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// Element classified import path element
+type Element struct {
+	Kind ElementKind
+	// Major is a version number for major version suffixes and API versions, i.e. 1 for v1beta1
+	Major int
+}
+
+// ClassifyElement classifies import path element. vN with N ≥ 2 are major version suffixes, v0, v1 and
+// vNalphaM, vNbetaM are API version packages, anything else is an ordinary path element
+func ClassifyElement(elem string) Element {
+	if !strings.HasPrefix(elem, "v") {
+		return Element{Kind: ElementOrdinary}
+	}
+
+	digits := elem[1:]
+	end := strings.IndexFunc(digits, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if end < 0 {
+		end = len(digits)
+	}
+	if end == 0 || (end > 1 && digits[0] == '0') {
+		return Element{Kind: ElementOrdinary}
+	}
+	major, err := strconv.Atoi(digits[:end])
+	if err != nil {
+		return Element{Kind: ElementOrdinary}
+	}
+
+	rest := digits[end:]
+	if rest == "" {
+		if major < 2 {
+			return Element{Kind: ElementAPIVersion, Major: major}
+		}
+		return Element{Kind: ElementMajor, Major: major}
+	}
+
+	for _, stage := range []string{"alpha", "beta"} {
+		if !strings.HasPrefix(rest, stage) {
+			continue
+		}
+		if isDigits(rest[len(stage):]) {
+			return Element{Kind: ElementAPIVersion, Major: major}
+		}
+	}
+
+	return Element{Kind: ElementOrdinary}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package replacer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// gopkgHost a host using .vN path element convention for major versions
+const gopkgHost = "gopkg.in/"
+
+// splitGopkg splits gopkg.in style path element, i.e. yaml.v2, into a name and a major version
+func splitGopkg(elem string) (name string, major int, ok bool) {
+	pos := strings.LastIndex(elem, ".v")
+	if pos <= 0 {
+		return "", 0, false
+	}
+
+	digits := elem[pos+2:]
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return "", 0, false
+	}
+	major, err := strconv.Atoi(digits)
+	if err != nil {
+		return "", 0, false
+	}
+	return elem[:pos], major, true
+}
+
+// isGopkg checks if the import path belongs to gopkg.in
+func isGopkg(importPath string) bool {
+	return strings.HasPrefix(importPath, gopkgHost)
+}
@@ -0,0 +1,20 @@
+package replacer
+
+var _ Replacer = chainReplace{}
+
+type chainReplace []Replacer
+
+// Chain a replacer applying the first matching replacer out of given ones. Import paths are never changed twice,
+// so rules of the chain do not affect each other
+func Chain(replacers ...Replacer) Replacer {
+	return chainReplace(replacers)
+}
+
+func (c chainReplace) Replace(old string) Variant {
+	for _, r := range c {
+		if v, ok := r.Replace(old).(Replacement); ok {
+			return v
+		}
+	}
+	return Nothing{}
+}
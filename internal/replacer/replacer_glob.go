@@ -0,0 +1,171 @@
+package replacer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var _ Replacer = &globReplace{}
+
+const (
+	globSegment  = "*"
+	globSegments = "**"
+)
+
+// globPiece one piece of a replacement template: either a literal text or a capture reference
+type globPiece struct {
+	text    string
+	capture int
+}
+
+type globReplace struct {
+	from []string
+	to   []globPiece
+}
+
+// Glob a replacer working with path segments. * in the pattern matches exactly one path segment,
+// ** matches any amount of them. Matched segments are available in the replacement as $1, $2, etc
+// (or ${1}, ${2}, etc) in the order of their appearance in the pattern
+func Glob(from, to string) (Replacer, error) {
+	from = strings.Trim(from, "/")
+	if len(from) == 0 {
+		return nil, errors.New("empty glob pattern")
+	}
+
+	var captures int
+	segments := strings.Split(from, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+			return nil, errors.Errorf("empty path segment at position %d of glob pattern", i+1)
+		case segment == globSegment || segment == globSegments:
+			captures++
+		case strings.Contains(segment, globSegment):
+			return nil, errors.Errorf("glob pattern segment `%s` mixes wildcard with a text, only whole segment wildcards are supported", segment)
+		}
+	}
+
+	pieces, err := parseGlobTemplate(to, captures)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid replacement for glob replacer")
+	}
+
+	return &globReplace{
+		from: segments,
+		to:   pieces,
+	}, nil
+}
+
+func (g *globReplace) Replace(old string) Variant {
+	captures, ok := globMatch(g.from, strings.Split(old, "/"), nil)
+	if !ok {
+		return Nothing{}
+	}
+
+	var buf strings.Builder
+	for _, piece := range g.to {
+		if piece.capture > 0 {
+			buf.WriteString(captures[piece.capture-1])
+			continue
+		}
+		buf.WriteString(piece.text)
+	}
+
+	// empty ** captures may leave empty segments behind
+	var res []string
+	for _, segment := range strings.Split(buf.String(), "/") {
+		if segment != "" {
+			res = append(res, segment)
+		}
+	}
+	return Replacement(strings.Join(res, "/"))
+}
+
+// globMatch matches path segments against the pattern and returns captured values
+func globMatch(pattern []string, segments []string, captures []string) ([]string, bool) {
+	if len(pattern) == 0 {
+		return captures, len(segments) == 0
+	}
+
+	switch pattern[0] {
+	case globSegment:
+		if len(segments) == 0 {
+			return nil, false
+		}
+		return globMatch(pattern[1:], segments[1:], appendCapture(captures, segments[0]))
+	case globSegments:
+		// try the longest match first
+		for i := len(segments); i >= 0; i-- {
+			res, ok := globMatch(pattern[1:], segments[i:], appendCapture(captures, strings.Join(segments[:i], "/")))
+			if ok {
+				return res, true
+			}
+		}
+		return nil, false
+	default:
+		if len(segments) == 0 || segments[0] != pattern[0] {
+			return nil, false
+		}
+		return globMatch(pattern[1:], segments[1:], captures)
+	}
+}
+
+// appendCapture appends captured value without touching captures' underlying array which can be
+// shared between alternative matches
+func appendCapture(captures []string, value string) []string {
+	return append(captures[:len(captures):len(captures)], value)
+}
+
+// parseGlobTemplate splits replacement into literal texts and capture references
+func parseGlobTemplate(to string, captures int) ([]globPiece, error) {
+	var res []globPiece
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			res = append(res, globPiece{text: buf.String()})
+			buf.Reset()
+		}
+	}
+
+	for rest := to; len(rest) > 0; {
+		pos := strings.IndexByte(rest, '$')
+		if pos < 0 {
+			buf.WriteString(rest)
+			break
+		}
+		buf.WriteString(rest[:pos])
+		rest = rest[pos+1:]
+
+		var ref string
+		if strings.HasPrefix(rest, "{") {
+			end := strings.IndexByte(rest, '}')
+			if end < 0 {
+				return nil, errors.New("unclosed ${ in replacement")
+			}
+			ref = rest[1:end]
+			rest = rest[end+1:]
+		} else {
+			end := 0
+			for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+				end++
+			}
+			ref = rest[:end]
+			rest = rest[end:]
+		}
+
+		index, err := strconv.Atoi(ref)
+		if err != nil {
+			return nil, errors.Errorf("capture number expected after $, got `%s`", ref)
+		}
+		if index < 1 || index > captures {
+			return nil, errors.Errorf("capture $%d is out of range, pattern has %d of them", index, captures)
+		}
+		flush()
+		res = append(res, globPiece{capture: index})
+	}
+	flush()
+
+	return res, nil
+}
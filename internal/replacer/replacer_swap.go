@@ -0,0 +1,58 @@
+package replacer
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var _ Replacer = &swapReplace{}
+
+type swapReplace struct {
+	first  string
+	second string
+}
+
+// Swap a replacer exchanging two import paths and their subpackages in a single pass, i.e. for swap of a/x and a/y
+//
+//	a/x/pkg => a/y/pkg
+//	a/y/pkg => a/x/pkg
+func Swap(first, second string) (Replacer, error) {
+	first = strings.Trim(first, "/")
+	second = strings.Trim(second, "/")
+	if len(first) == 0 || len(second) == 0 {
+		return nil, errors.New("swap requires two non-empty import paths")
+	}
+	if first == second {
+		return nil, errors.Errorf("cannot swap %s with itself", first)
+	}
+	return &swapReplace{
+		first:  first,
+		second: second,
+	}, nil
+}
+
+func (s *swapReplace) Replace(old string) Variant {
+	// the longest path wins in case if one of them is a subpackage of another
+	firstRest, firstOK := pathRest(old, s.first)
+	secondRest, secondOK := pathRest(old, s.second)
+	switch {
+	case firstOK && (!secondOK || len(s.first) > len(s.second)):
+		return Replacement(s.second + firstRest)
+	case secondOK:
+		return Replacement(s.first + secondRest)
+	default:
+		return Nothing{}
+	}
+}
+
+// pathRest checks if old is either base itself or its subpackage and returns the rest after the base
+func pathRest(old, base string) (string, bool) {
+	if old == base {
+		return "", true
+	}
+	if strings.HasPrefix(old, base+"/") {
+		return old[len(base):], true
+	}
+	return "", false
+}
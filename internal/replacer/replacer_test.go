@@ -152,6 +152,35 @@ func Test_replacerVersioned_Replace(t *testing.T) {
 			args: "example.com/project",
 			want: Nothing{},
 		},
+		{
+			name: "api-version-subpackage",
+			base: "github.com/user/project",
+			jump: 1,
+			args: "github.com/user/project/v1beta1",
+			want: Replacement("github.com/user/project/v2/v1beta1"),
+		},
+		{
+			name: "v1-subpackage",
+			base: "k8s.io/api",
+			jump: 1,
+			args: "k8s.io/api/v1/types",
+			want: Replacement("k8s.io/api/v2/v1/types"),
+		},
+		{
+			name: "ordinary-subpackage",
+			base: "github.com/user/project",
+			jump: 1,
+			args: "github.com/user/project/vendorx",
+			want: Replacement("github.com/user/project/v2/vendorx"),
+		},
+		{
+			name:          "init-error-api-version",
+			base:          "k8s.io/api/apps/v1beta1",
+			jump:          1,
+			args:          "",
+			wantInitError: true,
+			want:          nil,
+		},
 		{
 			name:          "init-error",
 			base:          "github.com/user/project/v1",
@@ -177,3 +206,527 @@ func Test_replacerVersioned_Replace(t *testing.T) {
 		})
 	}
 }
+
+func Test_globReplace_Replace(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          string
+		to            string
+		old           string
+		wantInitError bool
+		want          Variant
+	}{
+		{
+			name: "segments",
+			from: "github.com/org/*/proto/**",
+			to:   "gitlab.example.com/proto/$1/$2",
+			old:  "github.com/org/service/proto/v1/types",
+			want: Replacement("gitlab.example.com/proto/service/v1/types"),
+		},
+		{
+			name: "empty-many",
+			from: "github.com/org/*/proto/**",
+			to:   "gitlab.example.com/proto/$1/$2",
+			old:  "github.com/org/service/proto",
+			want: Replacement("gitlab.example.com/proto/service"),
+		},
+		{
+			name: "braces",
+			from: "gen/*",
+			to:   "gitlab.example.com/schema/${1}pb",
+			old:  "gen/marker",
+			want: Replacement("gitlab.example.com/schema/markerpb"),
+		},
+		{
+			name: "single-segment-only",
+			from: "gen/*",
+			to:   "gitlab.example.com/schema/$1",
+			old:  "gen/caddy/marker",
+			want: Nothing{},
+		},
+		{
+			name: "mismatch-2",
+			from: "gen/**",
+			to:   "gitlab.example.com/schema/$1",
+			old:  "gene/marker",
+			want: Nothing{},
+		},
+		{
+			name: "many-in-the-middle",
+			from: "gen/**/marker",
+			to:   "gitlab.example.com/schema/$1",
+			old:  "gen/a/b/marker",
+			want: Replacement("gitlab.example.com/schema/a/b"),
+		},
+		{
+			name:          "partial-segment",
+			from:          "gen/mark*",
+			to:            "gitlab.example.com/schema",
+			wantInitError: true,
+		},
+		{
+			name:          "capture-out-of-range",
+			from:          "gen/*",
+			to:            "gitlab.example.com/schema/$2",
+			wantInitError: true,
+		},
+		{
+			name:          "invalid-capture",
+			from:          "gen/*",
+			to:            "gitlab.example.com/schema/$name",
+			wantInitError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Glob(tt.from, tt.to)
+			if err != nil {
+				if tt.wantInitError {
+					return
+				}
+				t.Error(err)
+				return
+			}
+			if tt.wantInitError {
+				t.Errorf("Glob had to return an error on `%s` => `%s`", tt.from, tt.to)
+				return
+			}
+			if got := r.Replace(tt.old); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replace() = %#v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_swapReplace_Replace(t *testing.T) {
+	tests := []struct {
+		name          string
+		first         string
+		second        string
+		old           string
+		wantInitError bool
+		want          Variant
+	}{
+		{
+			name:   "first",
+			first:  "a/x",
+			second: "a/y",
+			old:    "a/x",
+			want:   Replacement("a/y"),
+		},
+		{
+			name:   "second",
+			first:  "a/x",
+			second: "a/y",
+			old:    "a/y",
+			want:   Replacement("a/x"),
+		},
+		{
+			name:   "subpackage",
+			first:  "a/x",
+			second: "a/y",
+			old:    "a/y/pkg",
+			want:   Replacement("a/x/pkg"),
+		},
+		{
+			name:   "partial-segment",
+			first:  "a/x",
+			second: "a/y",
+			old:    "a/xz/pkg",
+			want:   Nothing{},
+		},
+		{
+			name:   "nested",
+			first:  "a/x",
+			second: "a/x/y",
+			old:    "a/x/y/pkg",
+			want:   Replacement("a/x/pkg"),
+		},
+		{
+			name:          "same-paths",
+			first:         "a/x",
+			second:        "a/x/",
+			wantInitError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Swap(tt.first, tt.second)
+			if err != nil {
+				if tt.wantInitError {
+					return
+				}
+				t.Error(err)
+				return
+			}
+			if tt.wantInitError {
+				t.Errorf("Swap had to return an error on %s <=> %s", tt.first, tt.second)
+				return
+			}
+			if got := r.Replace(tt.old); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replace() = %#v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replacerVersioned_Downgraded(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		drop          int
+		args          string
+		wantInitError bool
+		want          Variant
+	}{
+		{
+			name: "decrement",
+			base: "github.com/user/project/v4",
+			drop: 1,
+			args: "github.com/user/project/v4/data",
+			want: Replacement("github.com/user/project/v3/data"),
+		},
+		{
+			name: "down-to-suffixless",
+			base: "github.com/user/project/v4",
+			drop: 3,
+			args: "github.com/user/project/v4",
+			want: Replacement("github.com/user/project"),
+		},
+		{
+			name: "other-major",
+			base: "github.com/user/project/v4",
+			drop: 1,
+			args: "github.com/user/project/v5/data",
+			want: Nothing{},
+		},
+		{
+			name: "gopkg",
+			base: "gopkg.in/yaml.v2",
+			drop: 1,
+			args: "gopkg.in/yaml.v2/sub",
+			want: Replacement("gopkg.in/yaml.v1/sub"),
+		},
+		{
+			name:          "below-v1",
+			base:          "github.com/user/project/v3",
+			drop:          3,
+			wantInitError: true,
+		},
+		{
+			name:          "suffixless",
+			base:          "github.com/user/project",
+			drop:          1,
+			wantInitError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Downgraded(tt.base, tt.drop)
+			if err != nil {
+				if tt.wantInitError {
+					return
+				}
+				t.Error(err)
+				return
+			}
+			if tt.wantInitError {
+				t.Errorf("Downgraded had to return an error on %s -= %d", tt.base, tt.drop)
+				return
+			}
+			if got := r.Replace(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replacerVersioned_Module(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		args string
+		want Variant
+	}{
+		{
+			name: "full-match",
+			from: "github.com/upstream/x",
+			to:   "github.com/fork/x",
+			args: "github.com/upstream/x",
+			want: Replacement("github.com/fork/x"),
+		},
+		{
+			name: "subpackage",
+			from: "github.com/upstream/x",
+			to:   "github.com/fork/x",
+			args: "github.com/upstream/x/sub/pkg",
+			want: Replacement("github.com/fork/x/sub/pkg"),
+		},
+		{
+			name: "api-version-subpackage",
+			from: "github.com/upstream/x",
+			to:   "github.com/fork/x",
+			args: "github.com/upstream/x/v1beta1",
+			want: Replacement("github.com/fork/x/v1beta1"),
+		},
+		{
+			name: "nested-major-module",
+			from: "github.com/upstream/x",
+			to:   "github.com/fork/x",
+			args: "github.com/upstream/x/v2/sub",
+			want: Nothing{},
+		},
+		{
+			name: "deprecated-in-favor-of-v2",
+			from: "github.com/foo/bar",
+			to:   "github.com/foo/bar/v2",
+			args: "github.com/foo/bar/v2/y",
+			want: Nothing{},
+		},
+		{
+			name: "deprecated-in-favor-of-v2-old-import",
+			from: "github.com/foo/bar",
+			to:   "github.com/foo/bar/v2",
+			args: "github.com/foo/bar/y",
+			want: Replacement("github.com/foo/bar/v2/y"),
+		},
+		{
+			name: "major-module",
+			from: "github.com/upstream/x/v3",
+			to:   "github.com/fork/x/v3",
+			args: "github.com/upstream/x/v3/sub",
+			want: Replacement("github.com/fork/x/v3/sub"),
+		},
+		{
+			name: "other-module",
+			from: "github.com/upstream/x",
+			to:   "github.com/fork/x",
+			args: "github.com/upstream/xy",
+			want: Nothing{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Module(tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.Replace(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replacerVersioned_Stripped(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		args          string
+		wantInitError bool
+		want          Variant
+	}{
+		{
+			name: "subpackage",
+			base: "github.com/user/project/v3",
+			args: "github.com/user/project/v3/data",
+			want: Replacement("github.com/user/project/data"),
+		},
+		{
+			name: "full-match",
+			base: "github.com/user/project/v3",
+			args: "github.com/user/project/v3",
+			want: Replacement("github.com/user/project"),
+		},
+		{
+			name:          "suffixless",
+			base:          "github.com/user/project",
+			wantInitError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Stripped(tt.base)
+			if err != nil {
+				if tt.wantInitError {
+					return
+				}
+				t.Error(err)
+				return
+			}
+			if tt.wantInitError {
+				t.Errorf("Stripped had to return an error on %s", tt.base)
+				return
+			}
+			if got := r.Replace(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replacerVersioned_Migrated(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		jump          int
+		to            string
+		args          string
+		wantInitError bool
+		want          Variant
+	}{
+		{
+			name: "gopkg",
+			base: "gopkg.in/yaml.v2",
+			jump: 1,
+			args: "gopkg.in/yaml.v2",
+			want: Replacement("gopkg.in/yaml.v3"),
+		},
+		{
+			name: "gopkg-subpackage",
+			base: "gopkg.in/user/pkg.v1",
+			jump: 2,
+			args: "gopkg.in/user/pkg.v1/sub",
+			want: Replacement("gopkg.in/user/pkg.v3/sub"),
+		},
+		{
+			name: "gopkg-other-major",
+			base: "gopkg.in/yaml.v2",
+			jump: 1,
+			args: "gopkg.in/yaml.v20",
+			want: Nothing{},
+		},
+		{
+			name: "gopkg-to-suffix",
+			base: "gopkg.in/foo.v1",
+			jump: 1,
+			to:   "github.com/foo/foo",
+			args: "gopkg.in/foo.v1/bar",
+			want: Replacement("github.com/foo/foo/v2/bar"),
+		},
+		{
+			name: "suffix-to-gopkg",
+			base: "github.com/foo/foo",
+			jump: 1,
+			to:   "gopkg.in/foo",
+			args: "github.com/foo/foo",
+			want: Replacement("gopkg.in/foo.v2"),
+		},
+		{
+			name: "move",
+			base: "github.com/user/project/v2",
+			jump: 1,
+			to:   "github.com/org/project",
+			args: "github.com/user/project/v2/data",
+			want: Replacement("github.com/org/project/v3/data"),
+		},
+		{
+			name:          "versioned-target",
+			base:          "gopkg.in/foo.v1",
+			jump:          1,
+			to:            "github.com/foo/foo/v2",
+			wantInitError: true,
+		},
+		{
+			name:          "gopkg-without-version",
+			base:          "gopkg.in/foo",
+			jump:          1,
+			wantInitError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Migrated(tt.base, tt.jump, tt.to)
+			if err != nil {
+				if tt.wantInitError {
+					return
+				}
+				t.Error(err)
+				return
+			}
+			if tt.wantInitError {
+				t.Errorf("Migrated had to return an error on %s += %d => %s", tt.base, tt.jump, tt.to)
+				return
+			}
+			if got := r.Replace(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyElement(t *testing.T) {
+	tests := []struct {
+		elem string
+		want Element
+	}{
+		{elem: "project", want: Element{Kind: ElementOrdinary}},
+		{elem: "vendorx", want: Element{Kind: ElementOrdinary}},
+		{elem: "v", want: Element{Kind: ElementOrdinary}},
+		{elem: "v02", want: Element{Kind: ElementOrdinary}},
+		{elem: "v2x", want: Element{Kind: ElementOrdinary}},
+		{elem: "v1gamma1", want: Element{Kind: ElementOrdinary}},
+		{elem: "v2", want: Element{Kind: ElementMajor, Major: 2}},
+		{elem: "v15", want: Element{Kind: ElementMajor, Major: 15}},
+		{elem: "v0", want: Element{Kind: ElementAPIVersion, Major: 0}},
+		{elem: "v1", want: Element{Kind: ElementAPIVersion, Major: 1}},
+		{elem: "v1beta1", want: Element{Kind: ElementAPIVersion, Major: 1}},
+		{elem: "v2alpha", want: Element{Kind: ElementAPIVersion, Major: 2}},
+		{elem: "v1alpha2", want: Element{Kind: ElementAPIVersion, Major: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.elem, func(t *testing.T) {
+			if got := ClassifyElement(tt.elem); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClassifyElement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitGopkg(t *testing.T) {
+	tests := []struct {
+		elem      string
+		wantName  string
+		wantMajor int
+		wantOK    bool
+	}{
+		{elem: "yaml.v2", wantName: "yaml", wantMajor: 2, wantOK: true},
+		{elem: "go-playground.v10", wantName: "go-playground", wantMajor: 10, wantOK: true},
+		{elem: "yaml", wantOK: false},
+		{elem: ".v2", wantOK: false},
+		{elem: "yaml.v", wantOK: false},
+		{elem: "yaml.v+2", wantOK: false},
+		{elem: "yaml.vault", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.elem, func(t *testing.T) {
+			name, major, ok := splitGopkg(tt.elem)
+			if name != tt.wantName || major != tt.wantMajor || ok != tt.wantOK {
+				t.Errorf("splitGopkg() = %v, %v, %v, want %v, %v, %v", name, major, ok, tt.wantName, tt.wantMajor, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_chainReplace_Replace(t *testing.T) {
+	r := Chain(
+		Prefix("a/x/", "a/y/"),
+		Prefix("a/y/", "a/z/"),
+	)
+	tests := []struct {
+		old  string
+		want Variant
+	}{
+		{old: "a/x/pkg", want: Replacement("a/y/pkg")},
+		{old: "a/y/pkg", want: Replacement("a/z/pkg")},
+		{old: "b/pkg", want: Nothing{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.old, func(t *testing.T) {
+			if got := r.Replace(tt.old); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replace() = %#v, want %v", got, tt.want)
+			}
+		})
+	}
+}
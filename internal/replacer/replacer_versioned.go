@@ -8,43 +8,196 @@ import (
 
 // Versioned a replaced that cares about major version suffixes
 func Versioned(base string, jump int) (Replacer, error) {
+	return Migrated(base, jump, "")
+}
+
+// Migrated a replacer that makes a major version jump moving a module into another path at the same time. The
+// new path must be given without major version, which is encoded into it using either /vN suffix or gopkg.in
+// .vN convention depending on the path. Module stays where it is when the new path is empty:
+//   gopkg.in/yaml.v2 with jump 1 and new path github.com/go-yaml/yaml => github.com/go-yaml/yaml/v3
+//   gopkg.in/yaml.v2 with jump 1 and empty new path                   => gopkg.in/yaml.v3
+func Migrated(base string, jump int, to string) (Replacer, error) {
+	cur, err := splitMajor(base)
+	if err != nil {
+		return nil, err
+	}
+
+	newVersion := cur.major + jump
+	if cur.major == 0 && !cur.gopkg {
+		newVersion++
+	}
+
+	target := cur
+	if to != "" {
+		target, err = splitTarget(to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newReplacerVersioned(base, cur, target.withMajor(newVersion)), nil
+}
+
+// Module a replacer moving a module into another path keeping its major version. Imports of greater major
+// versions of a suffixless module, i.e. github.com/user/project/v2 for github.com/user/project, belong to other
+// modules and are left intact
+func Module(from, to string) (Replacer, error) {
+	cur, err := splitMajor(from)
+	if err != nil {
+		return nil, err
+	}
+	to = strings.TrimRight(to, "/")
+	if to == "" {
+		return nil, fmt.Errorf("empty new module path for %s", from)
+	}
+
+	return newReplacerVersioned(from, cur, to), nil
+}
+
+// Downgraded a replacer lowering major version suffix by the given value. Major version suffix is stripped entirely
+// when the result is v1
+func Downgraded(base string, drop int) (Replacer, error) {
+	cur, err := splitMajor(base)
+	if err != nil {
+		return nil, err
+	}
+	if cur.major == 0 && !cur.gopkg {
+		return nil, fmt.Errorf("%s has no major version suffix, there is nothing to downgrade", base)
+	}
+	if drop < 1 {
+		return nil, fmt.Errorf("version drop must be positive, got %d", drop)
+	}
+
+	newVersion := cur.major - drop
+	if newVersion < 1 && !cur.gopkg || newVersion < 0 {
+		return nil, fmt.Errorf("cannot downgrade %s by %d: major version would become v%d", base, drop, newVersion)
+	}
+
+	return newReplacerVersioned(base, cur, cur.withMajor(newVersion)), nil
+}
+
+// Stripped a replacer removing major version suffix
+func Stripped(base string) (Replacer, error) {
+	cur, err := splitMajor(base)
+	if err != nil {
+		return nil, err
+	}
+	if cur.gopkg {
+		return nil, fmt.Errorf("%s: gopkg.in import paths always carry major version", base)
+	}
+	if cur.major == 0 {
+		return nil, fmt.Errorf("%s has no major version suffix, there is nothing to strip", base)
+	}
+
+	return newReplacerVersioned(base, cur, cur.withMajor(1)), nil
+}
+
+// CurrentMajor returns a path of the base without major version and its major version. Suffixless paths are
+// reported as v1 ones
+func CurrentMajor(base string) (string, int, error) {
+	cur, err := splitMajor(base)
+	if err != nil {
+		return "", 0, err
+	}
+	if cur.major == 0 && !cur.gopkg {
+		return cur.root, 1, nil
+	}
+	return cur.root, cur.major, nil
+}
+
+// majorPath import path split into a versionless part and a major version
+type majorPath struct {
+	// root is an import path without major version, i.e. github.com/user/project or gopkg.in/yaml
+	root string
+	// major is a major version, it is 0 for suffixless paths
+	major int
+	// gopkg is set for gopkg.in paths having major version in .vN form
+	gopkg bool
+}
+
+// withMajor renders the path with a given major version
+func (p majorPath) withMajor(major int) string {
+	if p.gopkg {
+		return fmt.Sprintf("%s.v%d", p.root, major)
+	}
+	if major > 1 {
+		return path.Join(p.root, fmt.Sprintf("v%d", major))
+	}
+	return p.root
+}
+
+// splitMajor splits base into a versionless part and a major version
+func splitMajor(base string) (majorPath, error) {
 	base = strings.TrimRight(base, "/")
 	suffixless, end := path.Split(base)
 
-	var sf Suffix
-	var curVersion int
-	if ok, _ := sf.Extract(end); ok {
-		if sf.Major < 2 {
-			return nil, fmt.Errorf("major version suffixes for versions lesser than 2 is a bad tone")
+	if isGopkg(base) {
+		if name, major, ok := splitGopkg(end); ok {
+			return majorPath{
+				root:  suffixless + name,
+				major: major,
+				gopkg: true,
+			}, nil
+		}
+		return majorPath{}, fmt.Errorf("gopkg.in import path %s must carry major version", base)
+	}
+
+	elem := ClassifyElement(end)
+	switch elem.Kind {
+	case ElementMajor:
+		return majorPath{
+			root:  strings.TrimRight(suffixless, "/"),
+			major: elem.Major,
+		}, nil
+	case ElementAPIVersion:
+		if end == "v0" || end == "v1" {
+			return majorPath{}, fmt.Errorf("major version suffixes for versions lesser than 2 is a bad tone")
 		}
-		curVersion = sf.Major
-	} else {
-		suffixless = base
+		return majorPath{}, fmt.Errorf("%s is an API version package rather than a major version suffix", end)
 	}
 
-	if curVersion == 0 {
-		jump++
+	return majorPath{root: base}, nil
+}
+
+// splitTarget checks a new module path for migration, which must be given without major version
+func splitTarget(to string) (majorPath, error) {
+	to = strings.TrimRight(to, "/")
+	if isGopkg(to) {
+		if _, _, ok := splitGopkg(path.Base(to)); ok {
+			return majorPath{}, fmt.Errorf("new module path %s must be given without major version", to)
+		}
+		return majorPath{root: to, gopkg: true}, nil
 	}
 
+	target, err := splitMajor(to)
+	if err != nil {
+		return majorPath{}, err
+	}
+	if target.major != 0 {
+		return majorPath{}, fmt.Errorf("new module path %s must be given without major version", to)
+	}
+	return target, nil
+}
+
+func newReplacerVersioned(base string, cur majorPath, importHead string) *replacerVersioned {
 	return &replacerVersioned{
 		base:       strings.TrimRight(base, "/") + "/",
-		importHead: path.Join(strings.TrimRight(suffixless, "/"), fmt.Sprintf("v%d", curVersion+jump)),
-		curVersion: curVersion,
-		newVersion: curVersion + jump,
-	}, nil
+		importHead: importHead,
+		suffixless: cur.major == 0 && !cur.gopkg,
+	}
 }
 
 type replacerVersioned struct {
 	base       string
 	importHead string
-	curVersion int
-	newVersion int
+	suffixless bool
 }
 
 // Replace - take jump = 1 for instance. In this case this will replace
 //   github.com/user/project    => github.com/user/project/v2 with base github.com/user/project
 //   github.com/user/project/v3 =>  github.com/user/project/v4 with base github.com/user/project/v3
 //   github.com/user/project/v2 => github.com/user/project/v2 with base github.com/user/project
+//   gopkg.in/yaml.v2           => gopkg.in/yaml.v3 with base gopkg.in/yaml.v2
 func (r *replacerVersioned) Replace(old string) Variant {
 	if !strings.HasPrefix(old, r.base) {
 		if old == strings.TrimRight(r.base, "/") {
@@ -55,10 +208,14 @@ func (r *replacerVersioned) Replace(old string) Variant {
 
 	rest := old[len(r.base):]
 
-	// it can be a greater major version import in case of suffixless base
-	if r.curVersion == 0 {
-		var sf Suffix
-		if ok, _ := sf.Extract(rest); ok {
+	// it can be a greater major version import in case of suffixless base, API version packages like v1beta1
+	// are ordinary subpackages though
+	if r.suffixless {
+		first := rest
+		if pos := strings.IndexByte(rest, '/'); pos >= 0 {
+			first = rest[:pos]
+		}
+		if ClassifyElement(first).Kind == ElementMajor {
 			return Nothing{}
 		}
 	}
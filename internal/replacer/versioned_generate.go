@@ -1,3 +0,0 @@
-package replacer
-
-//go:generate ldetool --go-string versioned.lde
@@ -0,0 +1,111 @@
+package scripts
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirkon/go-imports-rename/internal/importpath"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+// goCommandRe matches go command invocations, $(GO), ${GO} and $GO are common in Makefiles
+var goCommandRe = regexp.MustCompile(
+	`(?:^|[\s;&|(\x60"'])(?:go|\$\(GO\)|\$\{GO\}|\$GO)\s+(?:install|build|run|get|test|vet|list|doc|clean|generate|tool\s+\S+)(?:\s|$)`,
+)
+
+// linkerFlagRe matches -X importpath.name=value linker flags, the path with the name is captured
+var linkerFlagRe = regexp.MustCompile(`-X[\s=]+['"]?([^\s'"=]+)=`)
+
+// Rename applies the replacer to import paths of go command invocations like go install path@version or
+// go build path/... and to import paths of -X importpath.name=value linker flags found in the text of a Makefile,
+// Dockerfile or a shell script
+func Rename(data []byte, rep replacer.Replacer) []textedit.Edit {
+	src := string(data)
+	var res []textedit.Edit
+	seen := map[int]struct{}{}
+	add := func(start, end int, value string) {
+		if _, ok := seen[start]; ok {
+			return
+		}
+		seen[start] = struct{}{}
+		res = append(res, textedit.NewEdit(data, start, end, value))
+	}
+
+	for _, match := range linkerFlagRe.FindAllStringSubmatchIndex(src, -1) {
+		start, end := match[2], match[3]
+		pos := strings.LastIndexByte(src[start:end], '.')
+		if pos < 0 {
+			continue
+		}
+		path := src[start : start+pos]
+		if !importpath.IsRemote(path) {
+			continue
+		}
+		if v, ok := rep.Replace(path).(replacer.Replacement); ok {
+			add(start, start+pos, v.String())
+		}
+	}
+
+	for _, match := range goCommandRe.FindAllStringIndex(src, -1) {
+		for _, a := range commandArgs(src, match[1]) {
+			value := src[a.start:a.end]
+			if strings.HasPrefix(value, "-") {
+				continue
+			}
+			// package patterns like github.com/user/project/...
+			pattern := ""
+			if strings.HasSuffix(value, "/...") {
+				value, pattern = strings.TrimSuffix(value, "/..."), "/..."
+			}
+			newValue, ok := importpath.Replace(value, rep)
+			if !ok {
+				continue
+			}
+			if pattern != "" {
+				add(a.start, a.start+len(value), newValue)
+				continue
+			}
+			add(a.start, a.end, newValue)
+		}
+	}
+
+	return res
+}
+
+type arg struct {
+	start int
+	end   int
+}
+
+// commandArgs splits arguments of the command starting at the given position. The command ends with the line
+// unless it is continued with \, or with a shell control operator or a comment. Quotes are stripped
+func commandArgs(src string, pos int) []arg {
+	var res []arg
+	for i := pos; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\\' && i+1 < len(src) && src[i+1] == '\n':
+			i += 2
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '\n' || c == ';' || c == '&' || c == '|' || c == ')' || c == '#' || c == '`':
+			return res
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(src[i+1:], c)
+			if end < 0 {
+				return res
+			}
+			res = append(res, arg{start: i + 1, end: i + 1 + end})
+			i += end + 2
+		default:
+			j := i
+			for j < len(src) && !strings.ContainsRune(" \t\r\n;&|)`\"'", rune(src[j])) {
+				j++
+			}
+			res = append(res, arg{start: i, end: j})
+			i = j
+		}
+	}
+	return res
+}
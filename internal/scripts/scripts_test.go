@@ -0,0 +1,57 @@
+package scripts
+
+import (
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+)
+
+func TestRename(t *testing.T) {
+	rep := replacer.Prefix("github.com/org/", "github.com/neworg/")
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "go-install",
+			input: "RUN go install github.com/org/tool/cmd/x@latest && go version\n",
+			want:  "RUN go install github.com/neworg/tool/cmd/x@latest && go version\n",
+		},
+		{
+			name: "makefile",
+			input: `build:
+	$(GO) build -o bin/x \
+		-ldflags "-X github.com/org/svc/version.Commit=$(COMMIT) -X=github.com/org/svc/version.Date=$(DATE)" \
+		github.com/org/svc/cmd/x
+	go test -race github.com/org/svc/... # github.com/org/svc/comment
+`,
+			want: `build:
+	$(GO) build -o bin/x \
+		-ldflags "-X github.com/neworg/svc/version.Commit=$(COMMIT) -X=github.com/neworg/svc/version.Date=$(DATE)" \
+		github.com/neworg/svc/cmd/x
+	go test -race github.com/neworg/svc/... # github.com/org/svc/comment
+`,
+		},
+		{
+			name:  "shell",
+			input: "#!/bin/sh\nset -e\ngo run 'github.com/org/gen@v1.2.0' -out ./gen\necho github.com/org/svc\n",
+			want:  "#!/bin/sh\nset -e\ngo run 'github.com/neworg/gen@v1.2.0' -out ./gen\necho github.com/org/svc\n",
+		},
+		{
+			name:  "other-paths",
+			input: "go build ./cmd/x github.com/other/svc\ngo-bindata github.com/org/svc\n",
+			want:  "go build ./cmd/x github.com/other/svc\ngo-bindata github.com/org/svc\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := Rename([]byte(tt.input), rep)
+			if got := string(textedit.Apply([]byte(tt.input), edits)); got != tt.want {
+				t.Errorf("Rename() resulted in\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
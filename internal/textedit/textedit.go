@@ -0,0 +1,45 @@
+package textedit
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Edit a replacement of the Old text at [Start, End) with the New one
+type Edit struct {
+	// Line a line number of the edit starting from 1
+	Line  int
+	Start int
+	End   int
+	Old   string
+	New   string
+}
+
+// NewEdit creates an edit of data[start:end]
+func NewEdit(data []byte, start, end int, newText string) Edit {
+	return Edit{
+		Line:  bytes.Count(data[:start], []byte("\n")) + 1,
+		Start: start,
+		End:   end,
+		Old:   string(data[start:end]),
+		New:   newText,
+	}
+}
+
+// Apply applies non overlapping edits to data
+func Apply(data []byte, edits []Edit) []byte {
+	edits = append([]Edit(nil), edits...)
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Start < edits[j].Start
+	})
+
+	var buf bytes.Buffer
+	var last int
+	for _, edit := range edits {
+		buf.Write(data[last:edit.Start])
+		buf.WriteString(edit.New)
+		last = edit.End
+	}
+	buf.Write(data[last:])
+	return buf.Bytes()
+}
@@ -0,0 +1,24 @@
+package textedit
+
+import (
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	data := []byte("first old\nsecond old and old\n")
+	edits := []Edit{
+		NewEdit(data, 25, 28, "new"),
+		NewEdit(data, 6, 9, "newer"),
+		NewEdit(data, 17, 20, "new"),
+	}
+	for i, line := range []int{2, 1, 2} {
+		if edits[i].Line != line || edits[i].Old != "old" {
+			t.Errorf("edit %d is %#v, want line %d with old text", i, edits[i], line)
+		}
+	}
+
+	want := "first newer\nsecond new and new\n"
+	if got := string(Apply(data, edits)); got != want {
+		t.Errorf("Apply() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package vendoring
+
+import (
+	"strings"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// Move a change of a vendored module or package path
+type Move struct {
+	Old string
+	New string
+}
+
+// Rename applies the replacer to module and package paths of vendor/modules.txt. It returns updated modules.txt
+// contents, module path changes and package path changes. Package path changes include module roots as they
+// keep module files like LICENSE
+func Rename(data []byte, rep replacer.Replacer) ([]byte, []Move, []Move) {
+	var modules, packages []Move
+	seen := map[string]struct{}{}
+	addPackage := func(old, new string) {
+		if _, ok := seen[old]; ok {
+			return
+		}
+		seen[old] = struct{}{}
+		packages = append(packages, Move{Old: old, New: new})
+	}
+
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "## "):
+			// module annotations, i.e. ## explicit; go 1.18
+		case strings.HasPrefix(line, "# "):
+			// module line: # path version [=> replacement]
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			v, ok := rep.Replace(fields[1]).(replacer.Replacement)
+			if !ok {
+				continue
+			}
+			modules = append(modules, Move{Old: fields[1], New: v.String()})
+			addPackage(fields[1], v.String())
+			lines[i] = "# " + v.String() + line[len("# ")+len(fields[1]):]
+		default:
+			// package line
+			pkg := strings.TrimSpace(line)
+			if pkg == "" {
+				continue
+			}
+			v, ok := rep.Replace(pkg).(replacer.Replacement)
+			if !ok {
+				continue
+			}
+			addPackage(pkg, v.String())
+			lines[i] = v.String()
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n")), modules, packages
+}
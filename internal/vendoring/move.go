@@ -0,0 +1,62 @@
+package vendoring
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// MovePackage moves files of a vendored package directory into a directory of its new path. Subdirectories are left
+// where they are since they belong to other packages. Directories left empty are removed
+func MovePackage(vendorDir string, move Move) error {
+	oldDir := filepath.Join(vendorDir, filepath.FromSlash(move.Old))
+	newDir := filepath.Join(vendorDir, filepath.FromSlash(move.New))
+
+	entries, err := os.ReadDir(oldDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.WithMessagef(err, "read %s", oldDir)
+	}
+
+	var created bool
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if !created {
+			if err := os.MkdirAll(newDir, 0755); err != nil {
+				return errors.WithMessagef(err, "create %s", newDir)
+			}
+			created = true
+		}
+		if err := os.Rename(filepath.Join(oldDir, entry.Name()), filepath.Join(newDir, entry.Name())); err != nil {
+			return errors.WithMessagef(err, "move %s", filepath.Join(oldDir, entry.Name()))
+		}
+	}
+
+	return removeEmptyDirs(vendorDir, oldDir)
+}
+
+// removeEmptyDirs removes the directory and its parents up to the vendor directory as long as they are empty
+func removeEmptyDirs(vendorDir, dir string) error {
+	vendorDir = filepath.Clean(vendorDir)
+	for dir = filepath.Clean(dir); dir != vendorDir && len(dir) > len(vendorDir); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return errors.WithMessagef(err, "read %s", dir)
+		}
+		if len(entries) > 0 {
+			return nil
+		}
+		if err := os.Remove(dir); err != nil {
+			return errors.WithMessagef(err, "remove %s", dir)
+		}
+	}
+	return nil
+}
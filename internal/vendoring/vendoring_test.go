@@ -0,0 +1,85 @@
+package vendoring
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+func TestRename(t *testing.T) {
+	input := `# github.com/user/project v1.2.0
+## explicit; go 1.18
+github.com/user/project
+github.com/user/project/sub
+# github.com/other/lib v0.1.0 => github.com/user/lib v0.1.1
+## explicit
+github.com/other/lib
+`
+	got, modules, packages := Rename([]byte(input), replacer.Prefix("github.com/user/project/", "github.com/org/project/"))
+
+	want := `# github.com/org/project v1.2.0
+## explicit; go 1.18
+github.com/org/project
+github.com/org/project/sub
+# github.com/other/lib v0.1.0 => github.com/user/lib v0.1.1
+## explicit
+github.com/other/lib
+`
+	if string(got) != want {
+		t.Errorf("Rename() resulted in\n%s\nwant\n%s", got, want)
+	}
+	wantModules := []Move{{Old: "github.com/user/project", New: "github.com/org/project"}}
+	if !reflect.DeepEqual(modules, wantModules) {
+		t.Errorf("Rename() modules = %v, want %v", modules, wantModules)
+	}
+	wantPackages := []Move{
+		{Old: "github.com/user/project", New: "github.com/org/project"},
+		{Old: "github.com/user/project/sub", New: "github.com/org/project/sub"},
+	}
+	if !reflect.DeepEqual(packages, wantPackages) {
+		t.Errorf("Rename() packages = %v, want %v", packages, wantPackages)
+	}
+}
+
+func TestMovePackage(t *testing.T) {
+	vendorDir := t.TempDir()
+	for _, name := range []string{
+		"github.com/user/project/LICENSE",
+		"github.com/user/project/project.go",
+		"github.com/user/project/sub/sub.go",
+	} {
+		fileName := filepath.Join(vendorDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moves := []Move{
+		{Old: "github.com/user/project", New: "github.com/org/project"},
+		{Old: "github.com/user/project/sub", New: "github.com/org/project/sub"},
+	}
+	for _, move := range moves {
+		if err := MovePackage(vendorDir, move); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{
+		"github.com/org/project/LICENSE",
+		"github.com/org/project/project.go",
+		"github.com/org/project/sub/sub.go",
+	} {
+		if _, err := os.Stat(filepath.Join(vendorDir, filepath.FromSlash(name))); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(vendorDir, "github.com", "user")); !os.IsNotExist(err) {
+		t.Errorf("github.com/user directory was expected to be removed, got %v", err)
+	}
+}
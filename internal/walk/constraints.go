@@ -0,0 +1,75 @@
+package walk
+
+import (
+	"go/build"
+	"path/filepath"
+	"strings"
+)
+
+// BuildContext returns a context to select files by build constraints with, it is nil when no tags, GOOS or GOARCH
+// were given. Tags are comma separated
+func BuildContext(tags, goos, goarch string) *build.Context {
+	if tags == "" && goos == "" && goarch == "" {
+		return nil
+	}
+
+	ctx := build.Default
+	if goos != "" {
+		ctx.GOOS = goos
+	}
+	if goarch != "" {
+		ctx.GOARCH = goarch
+	}
+	var cgo bool
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			ctx.BuildTags = append(ctx.BuildTags, tag)
+			cgo = cgo || tag == "cgo"
+		}
+	}
+	// the go tool disables cgo when cross-compiling unless told otherwise
+	if (ctx.GOOS != build.Default.GOOS || ctx.GOARCH != build.Default.GOARCH) && !cgo {
+		ctx.CgoEnabled = false
+	}
+	return &ctx
+}
+
+// Constraints selects files by build constraints and keeps files excluded by them and files whose constraints
+// can't be evaluated
+type Constraints struct {
+	// Context selects files if set, everything is processed otherwise
+	Context *build.Context
+
+	Excluded    []string
+	Unevaluable []Failure
+}
+
+// Failure a file whose build constraints can't be evaluated
+type Failure struct {
+	Path string
+	Err  error
+}
+
+// Match checks if the file is to be processed. Everything is processed unless there is a context. Files with
+// constraints that can't be evaluated are processed as well
+func (c *Constraints) Match(path string) bool {
+	ctx := c.Context
+	if ctx == nil {
+		ctx = &build.Default
+	}
+
+	dir, name := filepath.Split(path)
+	ok, err := ctx.MatchFile(dir, name)
+	if err != nil {
+		c.Unevaluable = append(c.Unevaluable, Failure{
+			Path: path,
+			Err:  err,
+		})
+		return true
+	}
+	if !ok && c.Context != nil {
+		c.Excluded = append(c.Excluded, path)
+		return false
+	}
+	return true
+}
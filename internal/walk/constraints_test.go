@@ -0,0 +1,107 @@
+package walk
+
+import (
+	"go/build"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuildContext(t *testing.T) {
+	otherOS := "windows"
+	if build.Default.GOOS == otherOS {
+		otherOS = "linux"
+	}
+
+	if ctx := BuildContext("", "", ""); ctx != nil {
+		t.Errorf("BuildContext() = %v, want nil", ctx)
+	}
+
+	ctx := BuildContext("integration, e2e", "", "")
+	if !reflect.DeepEqual(ctx.BuildTags, []string{"integration", "e2e"}) {
+		t.Errorf("BuildContext() tags = %v", ctx.BuildTags)
+	}
+	if ctx.CgoEnabled != build.Default.CgoEnabled {
+		t.Errorf("BuildContext() cgo = %v, want host %v", ctx.CgoEnabled, build.Default.CgoEnabled)
+	}
+
+	if ctx := BuildContext("", otherOS, ""); ctx.GOOS != otherOS || ctx.CgoEnabled {
+		t.Errorf("BuildContext() goos = %s, cgo = %v, want %s without cgo", ctx.GOOS, ctx.CgoEnabled, otherOS)
+	}
+	if ctx := BuildContext("", "", "s390x"); build.Default.GOARCH != "s390x" && ctx.CgoEnabled {
+		t.Error("BuildContext() cgo must be disabled for another GOARCH")
+	}
+	if ctx := BuildContext("cgo", otherOS, ""); !ctx.CgoEnabled {
+		t.Error("BuildContext() cgo must be enabled with the cgo tag")
+	}
+}
+
+func TestConstraints_Match(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"plain.go":       "package x\n",
+		"x_windows.go":   "package x\n",
+		"x_linux.go":     "package x\n",
+		"integration.go": "//go:build integration\n\npackage x\n",
+		"cgo.go":         "//go:build cgo\n\npackage x\n",
+		"broken.go":      "//go:build linux &&\n\npackage x\n",
+	})
+	files := []string{"plain.go", "x_windows.go", "x_linux.go", "integration.go", "cgo.go", "broken.go"}
+
+	tests := []struct {
+		name            string
+		ctx             *build.Context
+		wantExcluded    []string
+		wantUnevaluable []string
+	}{
+		{
+			name:            "no-context",
+			ctx:             nil,
+			wantUnevaluable: []string{"broken.go"},
+		},
+		{
+			name:            "windows",
+			ctx:             BuildContext("", "windows", "amd64"),
+			wantExcluded:    []string{"x_linux.go", "integration.go", "cgo.go"},
+			wantUnevaluable: []string{"broken.go"},
+		},
+		{
+			name:            "linux-integration-cgo",
+			ctx:             BuildContext("integration,cgo", "linux", "amd64"),
+			wantExcluded:    []string{"x_windows.go"},
+			wantUnevaluable: []string{"broken.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Constraints{Context: tt.ctx}
+			var processed []string
+			for _, name := range files {
+				if c.Match(filepath.Join(root, name)) {
+					processed = append(processed, name)
+				}
+			}
+
+			var excluded []string
+			for _, path := range c.Excluded {
+				excluded = append(excluded, filepath.Base(path))
+			}
+			var unevaluable []string
+			for _, failure := range c.Unevaluable {
+				if failure.Err == nil {
+					t.Errorf("Match() no error for %s", failure.Path)
+				}
+				unevaluable = append(unevaluable, filepath.Base(failure.Path))
+			}
+			if !reflect.DeepEqual(excluded, tt.wantExcluded) {
+				t.Errorf("Match() excluded = %v, want %v", excluded, tt.wantExcluded)
+			}
+			if !reflect.DeepEqual(unevaluable, tt.wantUnevaluable) {
+				t.Errorf("Match() unevaluable = %v, want %v", unevaluable, tt.wantUnevaluable)
+			}
+			if len(processed)+len(excluded) != len(files) {
+				t.Errorf("Match() processed = %v, excluded = %v, want every file in one of them", processed, excluded)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package walk
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+
+	"github.com/sirkon/go-imports-rename/internal/ignore"
+)
+
+// ignoreFiles files with gitignore syntax that are looked for in every directory
+var ignoreFiles = []string{".gitignore", ".importsrenameignore"}
+
+// vcsDirs version control directories that are never walked through
+var vcsDirs = []string{".git", ".hg", ".svn", ".bzr"}
+
+// Options of the walk
+type Options struct {
+	// Root is a directory to walk through
+	Root string
+	// Vendor enables walking through vendor directories
+	Vendor bool
+	// Modules restricts the walk to files of given modules if set
+	Modules []string
+	// Includes restricts the walk to files matching them if set
+	Includes *ignore.List
+	// Excludes are files and directories to skip
+	Excludes *ignore.List
+	// Hidden enables walking through files and directories starting with . or _ the go tool ignores, version
+	// control directories are skipped anyway
+	Hidden bool
+}
+
+// HasModule checks if files of the module are to be walked through
+func (o Options) HasModule(module string) bool {
+	return len(o.Modules) == 0 || hasString(o.Modules, module)
+}
+
+// Func is called for every file found, the module is empty for files out of any module
+type Func func(path string, info os.FileInfo, module string) error
+
+// GoFiles walks through *.go files of the root directory tree, see Files
+func GoFiles(opts Options, fn Func) error {
+	return Files(opts, func(name string) bool {
+		return strings.HasSuffix(name, ".go")
+	}, fn)
+}
+
+// Files walks through files of the root directory tree whose names are accepted by match skipping directories
+// and files the go tool ignores (hidden ones are kept if asked for), version control directories, vendor
+// directories (unless told otherwise) and anything ignored by .gitignore and .importsrenameignore files or by
+// include and exclude patterns. Each file is attributed to the module of the closest go.mod up the tree. Files of
+// modules not listed in options are skipped if there is a list
+func Files(opts Options, match func(name string) bool, fn Func) error {
+	// dirModules[dir] is a module the directory belongs to
+	dirModules := map[string]string{}
+	// dirIgnores[dir] are ignore lists of the directory
+	dirIgnores := map[string][]*ignore.List{}
+	root := filepath.Clean(opts.Root)
+
+	return filepath.Walk(opts.Root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		path = filepath.Clean(path)
+		dir, base := filepath.Split(path)
+		dir = filepath.Clean(dir)
+		if info.IsDir() {
+			if path != root {
+				if opts.skips(base, true) || (base == "vendor" && !opts.Vendor) {
+					return filepath.SkipDir
+				}
+				if isIgnored(root, path, true, dirIgnores, opts) {
+					return filepath.SkipDir
+				}
+			}
+
+			lists, err := readIgnoreFiles(path)
+			if err != nil {
+				return err
+			}
+			dirIgnores[path] = lists
+
+			module, ok := readModulePath(path)
+			if !ok {
+				if path == root {
+					module = OwnerModule(path)
+				} else {
+					module = dirModules[dir]
+				}
+			}
+			dirModules[path] = module
+			return nil
+		}
+
+		if !match(base) || opts.skips(base, false) {
+			return nil
+		}
+		if isIgnored(root, path, false, dirIgnores, opts) {
+			return nil
+		}
+
+		module := dirModules[dir]
+		if !opts.HasModule(module) {
+			return nil
+		}
+
+		return fn(path, info, module)
+	})
+}
+
+// OwnerModule looks for a module of the directory up the tree
+func OwnerModule(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if module, ok := readModulePath(dir); ok {
+			return module
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ImportPath returns an import path of the package in the directory which is made of the path of the module found
+// up the tree and the directory path relative to the module root. It returns false when there is no module
+func ImportPath(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for moduleDir := dir; ; {
+		if module, ok := readModulePath(moduleDir); ok {
+			rel, err := filepath.Rel(moduleDir, dir)
+			if err != nil {
+				return "", false
+			}
+			return path.Join(module, filepath.ToSlash(rel)), true
+		}
+		parent := filepath.Dir(moduleDir)
+		if parent == moduleDir {
+			return "", false
+		}
+		moduleDir = parent
+	}
+}
+
+// skips checks if a file or a directory with this name is to be skipped. These are the ones the go tool ignores
+// and version control directories
+func (o Options) skips(name string, isDir bool) bool {
+	if isDir && (name == "testdata" || name == "node_modules" || hasString(vcsDirs, name)) {
+		return true
+	}
+	return !o.Hidden && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"))
+}
+
+// isIgnored checks the path against ignore files of its parent directories and include and exclude patterns
+func isIgnored(root, path string, isDir bool, dirIgnores map[string][]*ignore.List, opts Options) bool {
+	rootRel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	rootRel = filepath.ToSlash(rootRel)
+	if opts.Excludes != nil {
+		if ignored, _ := opts.Excludes.Match(rootRel, isDir); ignored {
+			return true
+		}
+	}
+	if !isDir && opts.Includes != nil && !isIncluded(opts.Includes, rootRel) {
+		return true
+	}
+
+	// parent directories from the root down to the closest one, deeper ignore files override upper ones
+	var dirs []string
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == root || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	var ignored bool
+	for i := len(dirs) - 1; i >= 0; i-- {
+		rel, err := filepath.Rel(dirs[i], path)
+		if err != nil {
+			continue
+		}
+		for _, list := range dirIgnores[dirs[i]] {
+			if v, matched := list.Match(filepath.ToSlash(rel), isDir); matched {
+				ignored = v
+			}
+		}
+	}
+	return ignored
+}
+
+// isIncluded checks the file path relative to the root against include patterns. Like in gitignore, a directory
+// match applies to everything below it, while deeper matches override upper ones
+func isIncluded(includes *ignore.List, rel string) bool {
+	var included bool
+	elems := strings.Split(rel, "/")
+	for i := 1; i <= len(elems); i++ {
+		if v, matched := includes.Match(strings.Join(elems[:i], "/"), i < len(elems)); matched {
+			included = v
+		}
+	}
+	return included
+}
+
+// readIgnoreFiles reads ignore files of the directory
+func readIgnoreFiles(dir string) ([]*ignore.List, error) {
+	var res []*ignore.List
+	for _, name := range ignoreFiles {
+		fileName := filepath.Join(dir, name)
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.WithMessagef(err, "read %s", fileName)
+		}
+		list, err := ignore.Parse(string(data))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parse %s", fileName)
+		}
+		res = append(res, list)
+	}
+	return res, nil
+}
+
+// readModulePath reads module path from go.mod of the directory
+func readModulePath(dir string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", false
+	}
+	return modfile.ModulePath(data), true
+}
+
+func hasString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+package walk
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sirkon/go-imports-rename/internal/ignore"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		fileName := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func mustParse(t *testing.T, input string) *ignore.List {
+	t.Helper()
+	list, err := ignore.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
+
+func TestGoFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                          "module github.com/user/mono\n",
+		"main.go":                         "package main\n",
+		"README.md":                       "# mono\n",
+		"lib/lib.go":                      "package lib\n",
+		"service/go.mod":                  "module github.com/user/mono/service\n",
+		"service/service.go":              "package service\n",
+		"service/internal/x/x.go":         "package x\n",
+		"service/tools/go.mod":            "module github.com/user/mono/service/tools\n",
+		"service/tools/tools.go":          "package tools\n",
+		"vendor/github.com/user/v/v.go":   "package v\n",
+		".hidden/hidden.go":               "package hidden\n",
+		"_attic/attic.go":                 "package attic\n",
+		"testdata/data.go":                "package data\n",
+		"lib/_skip.go":                    "package lib\n",
+		".gitignore":                      "/gen/\n",
+		"gen/gen.go":                      "package gen\n",
+		"legacy/.importsrenameignore":     "*.go\n!keep.go\n",
+		"legacy/old.go":                   "package legacy\n",
+		"legacy/keep.go":                  "package legacy\n",
+		"docs/example/example.go":         "package example\n",
+		"docs/example/example_nonmain.go": "package example\n",
+	})
+
+	tests := []struct {
+		name string
+		opts Options
+		want map[string]string
+	}{
+		{
+			name: "nested-modules",
+			opts: Options{Root: root},
+			want: map[string]string{
+				"main.go":                         "github.com/user/mono",
+				"lib/lib.go":                      "github.com/user/mono",
+				"service/service.go":              "github.com/user/mono/service",
+				"service/internal/x/x.go":         "github.com/user/mono/service",
+				"service/tools/tools.go":          "github.com/user/mono/service/tools",
+				"legacy/keep.go":                  "github.com/user/mono",
+				"docs/example/example.go":         "github.com/user/mono",
+				"docs/example/example_nonmain.go": "github.com/user/mono",
+			},
+		},
+		{
+			name: "subdirectory-root",
+			opts: Options{Root: filepath.Join(root, "service", "internal")},
+			want: map[string]string{
+				"service/internal/x/x.go": "github.com/user/mono/service",
+			},
+		},
+		{
+			name: "module-filter",
+			opts: Options{
+				Root:    root,
+				Modules: []string{"github.com/user/mono/service", "github.com/user/mono/service/tools"},
+			},
+			want: map[string]string{
+				"service/service.go":      "github.com/user/mono/service",
+				"service/internal/x/x.go": "github.com/user/mono/service",
+				"service/tools/tools.go":  "github.com/user/mono/service/tools",
+			},
+		},
+		{
+			name: "module-filter-unknown",
+			opts: Options{
+				Root:    root,
+				Modules: []string{"github.com/user/other"},
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "vendor",
+			opts: Options{
+				Root:     root,
+				Vendor:   true,
+				Includes: mustParse(t, "vendor/**"),
+			},
+			want: map[string]string{
+				"vendor/github.com/user/v/v.go": "github.com/user/mono",
+			},
+		},
+		{
+			name: "include-directory",
+			opts: Options{
+				Root:     root,
+				Includes: mustParse(t, "service/internal"),
+			},
+			want: map[string]string{
+				"service/internal/x/x.go": "github.com/user/mono/service",
+			},
+		},
+		{
+			name: "include-directory-slash",
+			opts: Options{
+				Root:     root,
+				Includes: mustParse(t, "docs/\n!*_nonmain.go\nlib/"),
+			},
+			want: map[string]string{
+				"docs/example/example.go": "github.com/user/mono",
+				"lib/lib.go":              "github.com/user/mono",
+			},
+		},
+		{
+			name: "include-exclude",
+			opts: Options{
+				Root:     root,
+				Includes: mustParse(t, "docs/**\nservice/**"),
+				Excludes: mustParse(t, "*_nonmain.go\ninternal/"),
+			},
+			want: map[string]string{
+				"docs/example/example.go": "github.com/user/mono",
+				"service/service.go":      "github.com/user/mono/service",
+				"service/tools/tools.go":  "github.com/user/mono/service/tools",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			err := GoFiles(tt.opts, func(path string, info os.FileInfo, module string) error {
+				rel, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				got[filepath.ToSlash(rel)] = module
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GoFiles() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwnerModule(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":            "module github.com/user/mono\n",
+		"service/go.mod":    "module github.com/user/mono/service\n",
+		"service/x/x.go":    "package x\n",
+		"lib/internal/a.go": "package internal\n",
+	})
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: ".", want: "github.com/user/mono"},
+		{dir: "lib/internal", want: "github.com/user/mono"},
+		{dir: "service", want: "github.com/user/mono/service"},
+		{dir: "service/x", want: "github.com/user/mono/service"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if got := OwnerModule(filepath.Join(root, filepath.FromSlash(tt.dir))); got != tt.want {
+				t.Errorf("OwnerModule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportPath(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"mono/go.mod":            "module github.com/user/mono\n",
+		"mono/service/go.mod":    "module github.com/user/mono/service\n",
+		"mono/service/x/x.go":    "package x\n",
+		"mono/lib/internal/a.go": "package internal\n",
+		"loose/a.go":             "package loose\n",
+	})
+
+	tests := []struct {
+		dir    string
+		want   string
+		wantOK bool
+	}{
+		{dir: "mono", want: "github.com/user/mono", wantOK: true},
+		{dir: "mono/lib/internal", want: "github.com/user/mono/lib/internal", wantOK: true},
+		{dir: "mono/service", want: "github.com/user/mono/service", wantOK: true},
+		{dir: "mono/service/x", want: "github.com/user/mono/service/x", wantOK: true},
+		{dir: "loose", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			got, ok := ImportPath(filepath.Join(root, filepath.FromSlash(tt.dir)))
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ImportPath() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFiles_Hidden(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                      "module github.com/user/svc\n",
+		"Makefile":                    "build:\n\tgo build ./...\n",
+		".gitlab-ci.yml":              "build:\n  script: go build ./...\n",
+		".github/workflows/ci.yml":    "jobs: {}\n",
+		".github/workflows/README.md": "# workflows\n",
+		"_scripts/build.sh":           "go build ./...\n",
+		".git/hooks/pre-commit.yml":   "go vet ./...\n",
+		"testdata/ci.yml":             "jobs: {}\n",
+	})
+	includes := mustParse(t, ".github/workflows/*.yml\n.gitlab-ci.yml\n*.sh\n*.yml\nMakefile")
+
+	tests := []struct {
+		name   string
+		hidden bool
+		want   []string
+	}{
+		{
+			name: "go-tool-rules",
+			want: []string{"Makefile"},
+		},
+		{
+			name:   "hidden",
+			hidden: true,
+			want:   []string{".github/workflows/ci.yml", ".gitlab-ci.yml", "Makefile", "_scripts/build.sh"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{
+				Root:     root,
+				Includes: includes,
+				Hidden:   tt.hidden,
+			}
+			var got []string
+			err := Files(opts, func(string) bool { return true }, func(path string, info os.FileInfo, _ string) error {
+				rel, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				got = append(got, filepath.ToSlash(rel))
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Files() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
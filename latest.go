@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/sirkon/go-imports-rename/internal/modcache"
+	parser2 "github.com/sirkon/go-imports-rename/internal/parser"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// resolveLatestJump computes a version jump to the highest major version of the module (or of the new module path
+// if it is set) available in the local module cache or file based GOPROXY
+func resolveLatestJump(rule parser2.Add) (int, error) {
+	root, curMajor, err := replacer.CurrentMajor(rule.Import)
+	if err != nil {
+		return 0, err
+	}
+	if rule.To != "" {
+		root = rule.To
+	}
+
+	latest, err := modcache.LatestMajor(modcache.Sources(), root)
+	if err != nil {
+		return 0, errors.WithMessage(err, "resolve latest major version")
+	}
+	if latest <= curMajor {
+		return 0, errors.Errorf("%s is already at the latest major version v%d available", rule.Import, latest)
+	}
+
+	return latest - curMajor, nil
+}
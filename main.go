@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/build"
 	"go/parser"
 	"go/token"
 	"io"
@@ -13,63 +14,262 @@ import (
 
 	"github.com/alexflint/go-arg"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 	"github.com/sirkon/gosrcfmt"
 
+	"github.com/sirkon/go-imports-rename/internal/generated"
+	"github.com/sirkon/go-imports-rename/internal/ignore"
 	parser2 "github.com/sirkon/go-imports-rename/internal/parser"
 	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/walk"
 )
 
 type args struct {
-	Root string   `arg:"--root" help:"root path to search go files in"`
-	Save bool     `arg:"-s,--save" help:"save changes"`
-	Rule RuleType `arg:"positional,required" help:"A rule to make import path changes"`
+	Root                string   `arg:"--root" help:"root path to search go files in"`
+	Save                bool     `arg:"-s,--save" help:"save changes"`
+	Suggest             bool     `arg:"--suggest" help:"look for newer major versions of imported modules in the module cache and suggest rules to upgrade"`
+	Deprecated          bool     `arg:"--deprecated" help:"replace imports of modules marked as deprecated in their go.mod files"`
+	DropReplace         bool     `arg:"--drop-replace" help:"turn module path replace directives of go.mod into rules, replace directives are dropped with --save"`
+	GoModDiff           []string `arg:"--gomod-diff" help:"infer rules from module path changes between two go.mod files, each one is either a file or git REV:PATH"`
+	Output              string   `arg:"-o,--output" help:"a file to write rules inferred with --gomod-diff into"`
+	Rules               string   `arg:"--rules" help:"a file with rules to apply, one per line"`
+	Vendor              bool     `arg:"--vendor" help:"rename vendored packages and vendor/modules.txt entries as well instead of leaving vendor directories alone"`
+	Module              []string `arg:"--module,separate" help:"restrict changes to files of the module, can be repeated"`
+	Include             []string `arg:"--include,separate" help:"process only files matching the pattern with gitignore syntax relative to the root, can be repeated"`
+	Exclude             []string `arg:"--exclude,separate" help:"skip files and directories matching the pattern with gitignore syntax relative to the root, can be repeated"`
+	Tags                string   `arg:"--tags" help:"comma separated build tags to select files with, all files are processed if none of --tags, --goos and --goarch is given"`
+	GOOS                string   `arg:"--goos" help:"target operating system to select files with"`
+	GOARCH              string   `arg:"--goarch" help:"target architecture to select files with"`
+	Scripts             []string `arg:"--scripts,separate" help:"change go commands and -X linker flags in files matching the pattern with gitignore syntax relative to the root, i.e. Makefile, Dockerfile or *.sh, can be repeated"`
+	ProtoImports        bool     `arg:"--proto-imports" help:"change directories of import statements of .proto files as well, for proto paths mirroring Go ones"`
+	StripImportComments bool     `arg:"--strip-import-comments" help:"remove import comments of package clauses instead of changing them"`
+	DocLinks            bool     `arg:"--doc-links" help:"change import paths of doc links in comments as well"`
+	Strings             string   `arg:"--strings" help:"look for string literals being import paths: report them or rewrite them as well"`
+	Generated           string   `arg:"--generated" help:"what to do with generated files: include (default), skip or report them with their generator commands"`
+	Rule                RuleType `arg:"positional" help:"A rule to make import path changes"`
+}
+
+// options options shared by the tool's modes
+type options struct {
+	root string
+	save bool
+	// vendor enables walking through vendor directories
+	vendor bool
+	// modules restricts walking to files of given modules if set
+	modules []string
+	// includes restricts walking to files matching them if set
+	includes *ignore.List
+	// excludes are files and directories to skip
+	excludes *ignore.List
+	// build selects files by build constraints if set
+	build *build.Context
+	// generated how to treat generated files
+	generated generatedPolicy
+	// stripImportComments removes import comments of package clauses
+	stripImportComments bool
+	// docLinks enables doc links rename
+	docLinks bool
+	// strings how to treat string literals being import paths
+	strings stringsPolicy
+	// protoImports enables rename of .proto files import statements
+	protoImports bool
+	// scripts are Makefiles, Dockerfiles and shell scripts to change go commands in
+	scripts *ignore.List
+}
+
+// options returns options shared by the tool's modes
+func (a args) options() (options, error) {
+	res := options{
+		root:    a.Root,
+		save:    a.Save,
+		vendor:  a.Vendor,
+		modules: a.Module,
+		build:   a.buildContext(),
+
+		stripImportComments: a.StripImportComments,
+		docLinks:            a.DocLinks,
+		protoImports:        a.ProtoImports,
+	}
+
+	var err error
+	res.generated, err = parseGeneratedPolicy(a.Generated)
+	if err != nil {
+		return options{}, errors.WithMessage(err, "invalid --generated")
+	}
+	res.strings, err = parseStringsPolicy(a.Strings)
+	if err != nil {
+		return options{}, errors.WithMessage(err, "invalid --strings")
+	}
+	if len(a.Include) > 0 {
+		res.includes, err = ignore.Parse(strings.Join(a.Include, "\n"))
+		if err != nil {
+			return options{}, errors.WithMessage(err, "invalid --include")
+		}
+	}
+	if len(a.Scripts) > 0 {
+		res.scripts, err = ignore.Parse(strings.Join(a.Scripts, "\n"))
+		if err != nil {
+			return options{}, errors.WithMessage(err, "invalid --scripts")
+		}
+	}
+	if len(a.Exclude) > 0 {
+		res.excludes, err = ignore.Parse(strings.Join(a.Exclude, "\n"))
+		if err != nil {
+			return options{}, errors.WithMessage(err, "invalid --exclude")
+		}
+	}
+	return res, nil
+}
+
+// walkOptions returns options to walk through the root directory tree with
+func (o options) walkOptions() walk.Options {
+	return walk.Options{
+		Root:     o.root,
+		Vendor:   o.vendor,
+		Modules:  o.modules,
+		Includes: o.includes,
+		Excludes: o.excludes,
+	}
 }
 
 func (args) Description() string {
-	return "A tool to change import paths based on either prefix switch or regular expressions"
+	return "A tool to change import paths based on either prefix switch, path globs or regular expressions"
 }
 
 func main() {
 	var inputArgs args
 	inputArgs.Root = "."
 	argParse := arg.MustParse(&inputArgs)
+	opts, err := inputArgs.options()
+	if err != nil {
+		argParse.Fail(err.Error())
+	}
+
+	if inputArgs.Suggest {
+		if inputArgs.Rule.Rule != nil {
+			argParse.Fail("no rule is needed for suggestions")
+		}
+		runSuggest(newLogger(), opts)
+		return
+	}
+	if inputArgs.Deprecated {
+		if inputArgs.Rule.Rule != nil {
+			argParse.Fail("no rule is needed for deprecated modules replacement")
+		}
+		runDeprecated(newLogger(), opts)
+		return
+	}
+	if inputArgs.DropReplace {
+		if inputArgs.Rule.Rule != nil {
+			argParse.Fail("no rule is needed for replace directives drop")
+		}
+		runDropReplace(newLogger(), opts)
+		return
+	}
+	if len(inputArgs.GoModDiff) > 0 {
+		if len(inputArgs.GoModDiff) != 2 {
+			argParse.Fail("--gomod-diff needs two go.mod files: before and after")
+		}
+		if inputArgs.Output == "" {
+			argParse.Fail("--output is required for --gomod-diff")
+		}
+		runGoModDiff(newLogger(), inputArgs.Root, inputArgs.GoModDiff[0], inputArgs.GoModDiff[1], inputArgs.Output)
+		return
+	}
 
 	var rep replacer.Replacer
-	switch v := inputArgs.Rule.Rule.(type) {
+	switch {
+	case inputArgs.Rules != "" && inputArgs.Rule.Rule != nil:
+		argParse.Fail("either rule or --rules must be given, not both")
+	case inputArgs.Rules != "":
+		rep, err = rulesReplacer(inputArgs.Rules)
+	case inputArgs.Rule.Rule != nil:
+		rep, err = ruleReplacer(inputArgs.Rule.Rule)
+	default:
+		argParse.Fail("rule is required")
+	}
+	if err != nil {
+		argParse.Fail(err.Error())
+	}
+
+	runRename(newLogger(), opts, rep)
+}
+
+// ruleReplacer creates a replacer for the rule
+func ruleReplacer(rule parser2.Rule) (replacer.Replacer, error) {
+	switch v := rule.(type) {
 	case parser2.Prefix:
-		rep = replacer.Prefix(v.From, v.To)
+		return replacer.Prefix(v.From, v.To), nil
+	case parser2.Module:
+		return replacer.Module(v.From, v.To)
 	case parser2.Add:
-		var err error
-		rep, err = replacer.Versioned(v.Import, v.Jump)
-		if err != nil {
-			argParse.Fail(err.Error())
+		if v.Latest {
+			jump, err := resolveLatestJump(v)
+			if err != nil {
+				return nil, err
+			}
+			v.Jump = jump
 		}
+		return replacer.Migrated(v.Import, v.Jump, v.To)
+	case parser2.Sub:
+		return replacer.Downgraded(v.Import, v.Jump)
+	case parser2.Strip:
+		return replacer.Stripped(v.Import)
 	case parser2.Regexp:
-		var err error
-		rep, err = replacer.Regexp(v.From, v.To)
-		if err != nil {
-			argParse.Fail(err.Error())
+		return replacer.Regexp(v.From, v.To)
+	case parser2.Glob:
+		return replacer.Glob(v.From, v.To)
+	case parser2.Swap:
+		return replacer.Swap(v.First, v.Second)
+	default:
+		return nil, errors.Errorf("unsupported rule %T", rule)
+	}
+}
+
+// rulesReplacer reads rules file and creates a replacer applying them
+func rulesReplacer(fileName string) (replacer.Replacer, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "read rules")
+	}
+
+	rules, err := parser2.ParseRules(string(data))
+	if err != nil {
+		if v, ok := err.(parser2.ParseError); ok {
+			return nil, errors.Errorf("invalid rule in %s, %s: %s", fileName, v.Report, v.Details)
 		}
+		return nil, errors.WithMessagef(err, "invalid rules in %s", fileName)
 	}
 
-	logger := newLogger()
+	var reps []replacer.Replacer
+	for _, rule := range rules {
+		rep, err := ruleReplacer(rule)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "rule '%s'", rule)
+		}
+		reps = append(reps, rep)
+	}
+	return replacer.Chain(reps...), nil
+}
 
+// runRename reports or saves import path changes made by the replacer in Go files of the root directory tree.
+// It returns false if there were errors
+func runRename(logger *zerolog.Logger, opts options, rep replacer.Replacer) bool {
+	root, save := opts.root, opts.save
 	var changesCounter int
 	var actualChanges int
 	var filesCounter int
-	err := filepath.Walk(inputArgs.Root, func(path string, info os.FileInfo, err error) error {
-		_, base := filepath.Split(path)
-		if info.IsDir() {
-			if strings.HasPrefix(base, ".") && len(base) > 1 {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-
-		if !strings.HasSuffix(path, ".go") {
+	report := moduleReport{}
+	constraints := walk.Constraints{Context: opts.build}
+	var genReport generatedReport
+	var stringMatches int
+	err := walk.GoFiles(opts.walkOptions(), func(path string, info os.FileInfo, module string) error {
+		if !constraints.Match(path) {
 			return nil
 		}
 		filesCounter++
+		stats := report.get(module)
+		stats.files++
 
 		fset := token.NewFileSet()
 		goFile, err := parser.ParseFile(fset, path, nil, parser.AllErrors|parser.ParseComments)
@@ -78,13 +278,27 @@ func main() {
 			return nil
 		}
 
+		isGenerated := generated.IsGenerated(goFile)
+		if isGenerated && opts.generated != generatedPolicyInclude {
+			var matches int
+			for _, imp := range goFile.Imports {
+				if _, ok := rep.Replace(strings.Trim(imp.Path.Value, `"`)).(replacer.Replacement); ok {
+					matches++
+				}
+			}
+			genReport.add(opts.generated, path, goFile, matches)
+			return nil
+		}
+
 		var localChanges int
+		imports := map[string]struct{}{}
 		for _, imp := range goFile.Imports {
 			pathValue := strings.Trim(imp.Path.Value, `"`)
 			rep := rep.Replace(pathValue)
 			switch v := rep.(type) {
 			case replacer.Replacement:
-				if !inputArgs.Save {
+				imports[v.String()] = struct{}{}
+				if !save {
 					logger.Info().Msgf("%s: import %s => %s", path, pathValue, v.String())
 				} else {
 					imp.Path.Value = fmt.Sprintf(`"%s"`, v.String())
@@ -92,15 +306,39 @@ func main() {
 				changesCounter++
 				localChanges++
 			case replacer.Nothing:
+				imports[pathValue] = struct{}{}
 				continue
 			default:
 				logger.Fatal().Msgf("invalid variant case %T", v)
 			}
 		}
 
-		if inputArgs.Save && localChanges > 0 {
+		if isGenerated {
+			genReport.add(opts.generated, path, goFile, localChanges)
+		}
+
+		directiveChanges := renameDirectives(logger, path, goFile, imports, rep, save)
+		directiveChanges += renameImportComment(logger, path, fset, goFile, rep, save, opts.stripImportComments)
+		if opts.docLinks {
+			directiveChanges += renameDocLinks(logger, path, goFile, rep, save)
+		}
+		if opts.strings != stringsPolicyIgnore {
+			matches := renameStrings(logger, fset, goFile, rep, save && opts.strings == stringsPolicyRewrite)
+			stringMatches += matches
+			if opts.strings == stringsPolicyRewrite {
+				directiveChanges += matches
+			}
+		}
+		changesCounter += directiveChanges
+		localChanges += directiveChanges
+		stats.changes += localChanges
+		if localChanges > 0 {
+			stats.changedFiles++
+		}
+
+		if save && localChanges > 0 {
 			// create some temporary file in
-			fullPath, err := getFullPath(inputArgs.Root, info.Name())
+			fullPath, err := getFullPath(root, info.Name())
 			if err != nil {
 				logger.Error().Err(err).Msgf("failed to resolve absolute path of %s", path)
 			}
@@ -132,11 +370,22 @@ func main() {
 		return nil
 	})
 
+	logConstraints(logger, &constraints)
+	genReport.log(logger, opts.generated)
+	if stringMatches > 0 {
+		if save && opts.strings == stringsPolicyRewrite {
+			logger.Warn().Msgf("%d string literals looking like import paths were changed, check the list above", stringMatches)
+		} else {
+			logger.Warn().Msgf("%d string literals look like matching import paths, check the list above", stringMatches)
+		}
+	}
+	report.log(logger, opts)
+
 	var filesMention string
 	switch filesCounter {
 	case 0:
-		logger.Warn().Msgf("no *.go files detected in %s", inputArgs.Root)
-		return
+		logger.Warn().Msgf("no *.go files detected in %s", root)
+		return renameNonGo(logger, opts, rep) && err == nil
 	case 1:
 		filesMention = "1 *.go file"
 	default:
@@ -146,7 +395,7 @@ func main() {
 	if changesCounter == 0 {
 		logger.Info().Msgf("no changes detected in %d files", filesCounter)
 	} else {
-		if inputArgs.Save {
+		if save {
 			if actualChanges < changesCounter {
 				switch actualChanges {
 				case 0:
@@ -169,7 +418,7 @@ func main() {
 		} else {
 			switch changesCounter {
 			case 0:
-				logger.Info().Msgf("no changes were detected in %s", changesCounter, filesMention)
+				logger.Info().Msgf("no changes were detected in %s", filesMention)
 			case 1:
 				logger.Info().Msgf("%d change was detected in %s", changesCounter, filesMention)
 			default:
@@ -178,8 +427,11 @@ func main() {
 		}
 	}
 	if err != nil {
-		logger.Error().Err(err).Msgf("failed to scan %s directory tree", inputArgs.Root)
+		logger.Error().Err(err).Msgf("failed to scan %s directory tree", root)
 	}
+
+	nonGoOK := renameNonGo(logger, opts, rep)
+	return err == nil && nonGoOK && (!save || actualChanges == changesCounter)
 }
 
 func getFullPath(root string, name string) (string, error) {
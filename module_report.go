@@ -0,0 +1,57 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/rs/zerolog"
+)
+
+// moduleStats rename statistics of a module
+type moduleStats struct {
+	files        int
+	changedFiles int
+	changes      int
+}
+
+// moduleReport rename statistics grouped per module
+type moduleReport map[string]*moduleStats
+
+func (r moduleReport) get(module string) *moduleStats {
+	stats, ok := r[module]
+	if !ok {
+		stats = &moduleStats{}
+		r[module] = stats
+	}
+	return stats
+}
+
+// log reports statistics per module when there are several modules or modules were chosen explicitly
+func (r moduleReport) log(logger *zerolog.Logger, opts options) {
+	for _, module := range opts.modules {
+		if _, ok := r[module]; !ok {
+			logger.Warn().Msgf("no *.go files of module %s found in %s", module, opts.root)
+		}
+	}
+	if len(r) < 2 && len(opts.modules) == 0 {
+		return
+	}
+
+	modules := make([]string, 0, len(r))
+	for module := range r {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	for _, module := range modules {
+		stats := r[module]
+		name := module
+		if name == "" {
+			name = "(no module)"
+		}
+		logger.Info().
+			Int("files", stats.files).
+			Int("changed-files", stats.changedFiles).
+			Int("changes", stats.changes).
+			Msgf("module %s", name)
+	}
+}
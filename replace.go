@@ -0,0 +1,84 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/gomod"
+	"github.com/sirkon/go-imports-rename/internal/modcache"
+	parser2 "github.com/sirkon/go-imports-rename/internal/parser"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// runDropReplace turns module path replace directives of go.mod in the root into module rules, reports or saves
+// changes they make and replaces replace directives with requirements of replacement modules when saving
+func runDropReplace(logger *zerolog.Logger, opts options) {
+	root, save := opts.root, opts.save
+	goModPath := filepath.Join(root, "go.mod")
+	modFile, err := gomod.Read(goModPath)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to read go.mod in %s", root)
+		return
+	}
+
+	reps := gomod.PathReplaces(modFile)
+	if len(reps) == 0 {
+		logger.Info().Msgf("no module path replace directives found in %s", goModPath)
+		return
+	}
+
+	sources := modcache.Sources()
+	var replacers []replacer.Replacer
+	var dropped []gomod.Replace
+	for _, rep := range reps {
+		// replaces of modules which are not required matter to dependencies only
+		if gomod.Requirement(modFile, rep) == nil {
+			logger.Info().Msgf("replace %s => %s %s is kept as %s is not required", rep.Old, rep.New, rep.NewVersion, rep.Old)
+			continue
+		}
+		// replacement module can only be required directly if it declares its own path
+		if err := modcache.CheckFork(sources, rep.New, rep.NewVersion); err != nil {
+			logger.Warn().Err(err).Msgf("replace %s => %s %s was skipped and is kept", rep.Old, rep.New, rep.NewVersion)
+			continue
+		}
+
+		rule := parser2.Module{
+			From: rep.Old,
+			To:   rep.New,
+		}
+		r, err := ruleReplacer(rule)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to create a rule for %s", rep.Old)
+			return
+		}
+		replacers = append(replacers, r)
+		dropped = append(dropped, rep)
+		logger.Info().Msgf("replace %s => %s %s, rule '%s'", rep.Old, rep.New, rep.NewVersion, rule)
+	}
+	if len(dropped) == 0 {
+		logger.Info().Msgf("no replace directives of %s can be dropped", goModPath)
+		return
+	}
+
+	if !runRename(logger, opts, replacer.Chain(replacers...)) {
+		if save {
+			logger.Warn().Msgf("%s was left intact because of errors", goModPath)
+		}
+		return
+	}
+	if !save {
+		logger.Info().Msgf("replace directives will be dropped from %s with --save", goModPath)
+		return
+	}
+
+	if err := gomod.DropReplaces(modFile, dropped); err != nil {
+		logger.Error().Err(err).Msgf("failed to drop replace directives from %s", goModPath)
+		return
+	}
+	if err := gomod.Write(goModPath, modFile); err != nil {
+		logger.Error().Err(err).Msgf("failed to update %s", goModPath)
+		return
+	}
+	logger.Info().Int("dropped", len(dropped)).Msgf("replace directives were dropped from %s", goModPath)
+}
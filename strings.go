@@ -0,0 +1,80 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/importpath"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+)
+
+// stringsPolicy how string literals having import paths are treated
+type stringsPolicy string
+
+const (
+	stringsPolicyIgnore  stringsPolicy = ""
+	stringsPolicyReport  stringsPolicy = "report"
+	stringsPolicyRewrite stringsPolicy = "rewrite"
+)
+
+func parseStringsPolicy(value string) (stringsPolicy, error) {
+	switch policy := stringsPolicy(value); policy {
+	case stringsPolicyIgnore, stringsPolicyReport, stringsPolicyRewrite:
+		return policy, nil
+	default:
+		return "", errors.Errorf("unsupported string literals policy %s, either report or rewrite expected", value)
+	}
+}
+
+// renameStrings looks for string literals out of import declarations whose whole value is an import path or
+// a path@version spec matching the replacer. Every site is reported as it is a guess, literals are changed when
+// rewrite is set. It returns the amount of matching literals
+func renameStrings(
+	logger *zerolog.Logger,
+	fset *token.FileSet,
+	goFile *ast.File,
+	rep replacer.Replacer,
+	rewrite bool,
+) int {
+	var res int
+	ast.Inspect(goFile, func(node ast.Node) bool {
+		switch v := node.(type) {
+		case *ast.ImportSpec:
+			return false
+		case *ast.BasicLit:
+			if v.Kind != token.STRING {
+				return false
+			}
+			value, err := strconv.Unquote(v.Value)
+			if err != nil {
+				return false
+			}
+			newValue, ok := importpath.Replace(value, rep)
+			if !ok {
+				return false
+			}
+
+			res++
+			logger.Info().Msgf("%s: string %s => %s", fset.Position(v.Pos()), v.Value, quoteLike(v.Value, newValue))
+			if rewrite {
+				v.Value = quoteLike(v.Value, newValue)
+			}
+			return false
+		}
+		return true
+	})
+	return res
+}
+
+// quoteLike quotes the value the way the literal is quoted
+func quoteLike(literal string, value string) string {
+	if strings.HasPrefix(literal, "`") {
+		return "`" + value + "`"
+	}
+	return strconv.Quote(value)
+}
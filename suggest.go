@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/modcache"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/walk"
+)
+
+// suggestion an upgrade suggestion for a module
+type suggestion struct {
+	module string
+	jump   int
+	latest int
+	files  map[string]struct{}
+	count  int
+}
+
+// rule returns a rule to make the upgrade
+func (s *suggestion) rule() string {
+	if s.jump == 1 {
+		return s.module + " ++"
+	}
+	return fmt.Sprintf("%s += %d", s.module, s.jump)
+}
+
+// runSuggest looks for imported modules having newer major versions in the module cache or file based GOPROXY
+// and prints rules to upgrade them
+func runSuggest(logger *zerolog.Logger, opts options) {
+	root := opts.root
+	sources := modcache.Sources()
+
+	// modules[import path] is a module of the import, empty for imports whose module is unknown
+	modules := map[string]string{}
+	// suggestions[module] is nil for modules which are at their latest version
+	suggestions := map[string]*suggestion{}
+
+	var filesCounter int
+	constraints := walk.Constraints{Context: opts.build}
+	err := walk.GoFiles(opts.walkOptions(), func(path string, info os.FileInfo, _ string) error {
+		if !constraints.Match(path) {
+			return nil
+		}
+		filesCounter++
+
+		fset := token.NewFileSet()
+		goFile, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to parse %s", path)
+			return nil
+		}
+
+		for _, imp := range goFile.Imports {
+			pathValue := strings.Trim(imp.Path.Value, `"`)
+			if !strings.Contains(strings.Split(pathValue, "/")[0], ".") {
+				// standard library
+				continue
+			}
+
+			mod, ok := modules[pathValue]
+			if !ok {
+				mod, _, err = modcache.ModuleOf(sources, pathValue)
+				if err != nil {
+					logger.Error().Err(err).Msgf("failed to look for a module of %s", pathValue)
+				}
+				modules[pathValue] = mod
+			}
+			if mod == "" {
+				continue
+			}
+
+			s, ok := suggestions[mod]
+			if !ok {
+				s, err = suggestUpgrade(sources, mod)
+				if err != nil {
+					logger.Warn().Err(err).Msgf("failed to check newer versions of %s", mod)
+				}
+				suggestions[mod] = s
+			}
+			if s == nil {
+				continue
+			}
+
+			s.files[path] = struct{}{}
+			s.count++
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to scan %s directory tree", root)
+	}
+	logConstraints(logger, &constraints)
+
+	var res []*suggestion
+	for _, s := range suggestions {
+		if s != nil {
+			res = append(res, s)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].module < res[j].module
+	})
+
+	if filesCounter == 0 {
+		logger.Warn().Msgf("no *.go files detected in %s", root)
+		return
+	}
+	if len(res) == 0 {
+		logger.Info().Msgf("no upgrades found for imports in %d *.go files", filesCounter)
+		return
+	}
+	for _, s := range res {
+		logger.Info().
+			Int("files", len(s.files)).
+			Int("imports", s.count).
+			Msgf("'%s' (v%d is available)", s.rule(), s.latest)
+	}
+}
+
+// suggestUpgrade returns nil if the module is at its latest major version
+func suggestUpgrade(sources []string, module string) (*suggestion, error) {
+	root, curMajor, err := replacer.CurrentMajor(module)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := modcache.LatestMajor(sources, root)
+	if err != nil {
+		return nil, err
+	}
+	if latest <= curMajor {
+		return nil, nil
+	}
+
+	return &suggestion{
+		module: module,
+		jump:   latest - curMajor,
+		latest: latest,
+		files:  map[string]struct{}{},
+	}, nil
+}
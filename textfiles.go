@@ -0,0 +1,168 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/bazel"
+	"github.com/sirkon/go-imports-rename/internal/golangci"
+	"github.com/sirkon/go-imports-rename/internal/proto"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/scripts"
+	"github.com/sirkon/go-imports-rename/internal/textedit"
+	"github.com/sirkon/go-imports-rename/internal/walk"
+)
+
+// renameNonGo applies the replacer to files other than Go sources: go.work, vendor and text files referencing
+// import paths. It returns false if there were errors
+func renameNonGo(logger *zerolog.Logger, opts options, rep replacer.Replacer) bool {
+	workOK := renameGoWork(logger, opts, rep)
+	vendorOK := processVendor(logger, opts, rep)
+	protoOK := renameTextFiles(logger, opts, opts.walkOptions(), "*.proto", func(path string) bool {
+		return strings.HasSuffix(path, ".proto")
+	}, func(data []byte) []textedit.Edit {
+		return proto.Rename(data, rep, opts.protoImports)
+	})
+	bazelOK := renameTextFiles(logger, opts, opts.walkOptions(), "Bazel", func(path string) bool {
+		return bazel.IsBuildFile(filepath.Base(path))
+	}, func(data []byte) []textedit.Edit {
+		return bazel.Rename(data, rep)
+	})
+
+	scriptsOK := true
+	if opts.scripts != nil {
+		// scripts are asked for explicitly and often live in places like .github/workflows
+		walkOpts := opts.walkOptions()
+		walkOpts.Hidden = true
+		scriptsOK = renameTextFiles(logger, opts, walkOpts, "script", func(path string) bool {
+			rel, err := filepath.Rel(opts.root, path)
+			if err != nil {
+				return false
+			}
+			matched, _ := opts.scripts.Match(filepath.ToSlash(rel), false)
+			return matched
+		}, func(data []byte) []textedit.Edit {
+			return scripts.Rename(data, rep)
+		})
+	}
+	golangciOK := renameGolangci(logger, opts, rep)
+	return workOK && vendorOK && protoOK && bazelOK && scriptsOK && golangciOK
+}
+
+// renameGolangci applies the replacer to import paths of golangci-lint configuration of the root if there is one.
+// It returns false if there were errors
+func renameGolangci(logger *zerolog.Logger, opts options, rep replacer.Replacer) bool {
+	if !opts.walkOptions().HasModule(walk.OwnerModule(opts.root)) {
+		// the configuration belongs to the root module which is out of interest
+		return true
+	}
+
+	ok := true
+	for _, name := range golangci.FileNames {
+		path := filepath.Join(opts.root, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logger.Error().Err(err).Msgf("failed to check %s", path)
+				ok = false
+			}
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to read %s", path)
+			ok = false
+			continue
+		}
+		edits, err := golangci.Rename(data, rep)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to process %s", path)
+			ok = false
+			continue
+		}
+		if len(edits) == 0 {
+			continue
+		}
+
+		if !applyEdits(logger, opts, path, data, info.Mode(), edits) {
+			ok = false
+			continue
+		}
+		if opts.save {
+			logger.Info().Int("changes", len(edits)).Msgf("%s was updated", path)
+		}
+	}
+	return ok
+}
+
+// applyEdits reports edits of the file or saves them. It returns false if there were errors
+func applyEdits(logger *zerolog.Logger, opts options, path string, data []byte, mode os.FileMode, edits []textedit.Edit) bool {
+	if !opts.save {
+		for _, edit := range edits {
+			logger.Info().Msgf("%s:%d: %s => %s", path, edit.Line, edit.Old, edit.New)
+		}
+		return true
+	}
+
+	if err := os.WriteFile(path, textedit.Apply(data, edits), mode); err != nil {
+		logger.Error().Err(err).Msgf("failed to update %s", path)
+		return false
+	}
+	return true
+}
+
+// renameTextFiles applies edits computed by rename to files of the root directory tree whose paths are accepted by
+// match. Edits are reported or saved. It returns false if there were errors
+func renameTextFiles(
+	logger *zerolog.Logger,
+	opts options,
+	walkOpts walk.Options,
+	kind string,
+	match func(path string) bool,
+	rename func(data []byte) []textedit.Edit,
+) bool {
+	var changes int
+	var files int
+	ok := true
+	err := walk.Files(walkOpts, func(string) bool { return true }, func(path string, info os.FileInfo, _ string) error {
+		if !match(path) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to read %s", path)
+			ok = false
+			return nil
+		}
+
+		edits := rename(data)
+		if len(edits) == 0 {
+			return nil
+		}
+		changes += len(edits)
+		files++
+		if !applyEdits(logger, opts, path, data, info.Mode(), edits) {
+			ok = false
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to scan %s directory tree for %s files", opts.root, kind)
+		return false
+	}
+
+	if changes > 0 {
+		event := logger.Info().Int("changes", changes).Int("files", files)
+		if opts.save {
+			event.Msgf("%s files were updated", kind)
+		} else {
+			event.Msgf("changes were detected in %s files", kind)
+		}
+	}
+	return ok
+}
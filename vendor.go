@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/gomod"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/vendoring"
+	"github.com/sirkon/go-imports-rename/internal/walk"
+)
+
+// processVendor takes care of the vendor directory of the root if there is one. It is left intact by default with
+// a warning if vendored packages are affected by the replacer. Vendored package directories, vendor/modules.txt
+// entries and go.mod requirements are renamed in the vendor mode. It returns false if there were errors
+func processVendor(logger *zerolog.Logger, opts options, rep replacer.Replacer) bool {
+	if !opts.walkOptions().HasModule(walk.OwnerModule(opts.root)) {
+		// the vendor directory belongs to the root module which is out of interest
+		return true
+	}
+
+	vendorDir := filepath.Join(opts.root, "vendor")
+	modulesTxtPath := filepath.Join(vendorDir, "modules.txt")
+	data, err := os.ReadFile(modulesTxtPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true
+		}
+		logger.Error().Err(err).Msgf("failed to read %s", modulesTxtPath)
+		return false
+	}
+
+	modulesTxt, modules, packages := vendoring.Rename(data, rep)
+	if len(packages) == 0 {
+		return true
+	}
+
+	if !opts.vendor {
+		logger.Warn().
+			Int("modules", len(modules)).
+			Int("packages", len(packages)).
+			Msgf("%s was left intact while vendored packages are affected, rerun go mod vendor or use --vendor", vendorDir)
+		return true
+	}
+
+	if !opts.save {
+		for _, module := range modules {
+			logger.Info().Msgf("%s: module %s => %s", modulesTxtPath, module.Old, module.New)
+		}
+		for _, pkg := range packages {
+			logger.Info().Msgf("%s: package %s => %s", vendorDir, pkg.Old, pkg.New)
+		}
+		return true
+	}
+
+	for _, pkg := range packages {
+		if err := vendoring.MovePackage(vendorDir, pkg); err != nil {
+			logger.Error().Err(err).Msgf("failed to move vendored package %s", pkg.Old)
+			return false
+		}
+	}
+	info, err := os.Stat(modulesTxtPath)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to update %s", modulesTxtPath)
+		return false
+	}
+	if err := os.WriteFile(modulesTxtPath, modulesTxt, info.Mode()); err != nil {
+		logger.Error().Err(err).Msgf("failed to update %s", modulesTxtPath)
+		return false
+	}
+	logger.Info().Int("packages", len(packages)).Msgf("vendored packages were moved in %s", vendorDir)
+
+	// requirements must match modules.txt for vendoring to stay consistent
+	goModPath := filepath.Join(opts.root, "go.mod")
+	modFile, err := gomod.Read(goModPath)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to read %s", goModPath)
+		return false
+	}
+	if changes := gomod.RenameRequires(modFile, rep); len(changes) > 0 {
+		if err := gomod.Write(goModPath, modFile); err != nil {
+			logger.Error().Err(err).Msgf("failed to update %s", goModPath)
+			return false
+		}
+		logger.Info().Int("changes", len(changes)).Msgf("requirements of %s were updated", goModPath)
+	}
+
+	return true
+}
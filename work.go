@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sirkon/go-imports-rename/internal/gomod"
+	"github.com/sirkon/go-imports-rename/internal/replacer"
+	"github.com/sirkon/go-imports-rename/internal/walk"
+)
+
+// renameGoWork applies the replacer to go.work of the root if there is one: module paths of replace directives
+// are reported or saved and modules of use directives affected by the replacer are reported. It returns false if
+// there were errors
+func renameGoWork(logger *zerolog.Logger, opts options, rep replacer.Replacer) bool {
+	if !opts.walkOptions().HasModule(walk.OwnerModule(opts.root)) {
+		// go.work belongs to the root module which is out of interest
+		return true
+	}
+
+	root, save := opts.root, opts.save
+	workPath := filepath.Join(root, "go.work")
+	if _, err := os.Stat(workPath); err != nil {
+		if os.IsNotExist(err) {
+			return true
+		}
+		logger.Error().Err(err).Msgf("failed to check %s", workPath)
+		return false
+	}
+
+	work, err := gomod.ReadWork(workPath)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to read %s", workPath)
+		return false
+	}
+
+	ok := true
+	used, err := gomod.UsedModules(root, work)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to read modules used in %s", workPath)
+		ok = false
+	}
+	for _, u := range used {
+		if v, isReplacement := rep.Replace(u.Path).(replacer.Replacement); isReplacement {
+			logger.Warn().Msgf(
+				"%s: module %s used from %s is renamed to %s, update module directive in its go.mod to keep the workspace resolving",
+				workPath,
+				u.Path,
+				u.Dir,
+				v.String(),
+			)
+		}
+	}
+
+	changes := gomod.RenameWorkReplaces(work, rep)
+	if len(changes) == 0 {
+		return ok
+	}
+	for _, change := range changes {
+		if !save {
+			logger.Info().Msgf("%s: replace directive module path %s => %s", workPath, change.Old, change.New)
+		}
+	}
+	if !save {
+		return ok
+	}
+
+	if err := gomod.WriteWork(workPath, work); err != nil {
+		logger.Error().Err(err).Msgf("failed to update %s", workPath)
+		return false
+	}
+	logger.Info().Int("changes", len(changes)).Msgf("replace directives of %s were updated", workPath)
+	return ok
+}